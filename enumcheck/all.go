@@ -0,0 +1,268 @@
+package enumcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// collectAllValues finds slices and arrays annotated with `//enumcheck:all`.
+//
+//	//enumcheck:all
+//	var AllLetters = []Letter{Alpha, Beta, Gamma}
+//
+//	//enumcheck:all
+//	func AllLetters() []Letter { return []Letter{Alpha, Beta, Gamma} }
+func collectAllValues(pass *analysis.Pass) []*ast.CompositeLit {
+	var lits []*ast.CompositeLit
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.GenDecl:
+				if decl.Tok != token.VAR {
+					continue
+				}
+				declAll := hasAllComment(decl.Doc)
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.ValueSpec)
+					if !declAll && !hasAllComment(spec.Doc) && !hasAllComment(spec.Comment) {
+						continue
+					}
+					for _, value := range spec.Values {
+						if lit, ok := ast.Unparen(value).(*ast.CompositeLit); ok {
+							lits = append(lits, lit)
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if decl.Body == nil || !hasAllComment(decl.Doc) {
+					continue
+				}
+				ast.Inspect(decl.Body, func(n ast.Node) bool {
+					switch n := n.(type) {
+					case *ast.FuncLit:
+						return false
+					case *ast.ReturnStmt:
+						if len(n.Results) == 1 {
+							if lit, ok := ast.Unparen(n.Results[0]).(*ast.CompositeLit); ok {
+								lits = append(lits, lit)
+							}
+						}
+					}
+					return true
+				})
+			}
+		}
+	}
+
+	return lits
+}
+
+func hasAllComment(group *ast.CommentGroup) bool {
+	if group == nil {
+		return false
+	}
+	for _, c := range group.List {
+		if c, ok := isEnumcheckComment(c.Text); ok && c.all {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyAllValues checks that lit contains every value of the enum exactly once.
+func verifyAllValues(reportf reportFn, checkOverride overrideFn, pass *analysis.Pass, enums enumSet, lit *ast.CompositeLit) {
+	var elem types.Type
+	switch t := pass.TypesInfo.TypeOf(lit).Underlying().(type) {
+	case *types.Slice:
+		elem = t.Elem()
+	case *types.Array:
+		elem = t.Elem()
+	default:
+		reportf(lit.Pos(), "enumcheck:all must be a slice or an array")
+		return
+	}
+
+	enum, ok := enums.Of(elem)
+	if !ok {
+		reportf(lit.Pos(), "enumcheck:all element type %v is not an enum", elem)
+		return
+	}
+
+	// any list that differs from the enum values can be regenerated
+	var fixes []analysis.SuggestedFix
+	if !enum.TypeEnum {
+		fixes = []analysis.SuggestedFix{{
+			Message:   "Regenerate the list of values",
+			TextEdits: []analysis.TextEdit{allValuesEdit(pass, enum, lit)},
+		}}
+	}
+	reportFix := func(pos token.Pos, message string) {
+		reportDiagnostic(pass, checkOverride, analysis.Diagnostic{
+			Pos:            pos,
+			Message:        message,
+			SuggestedFixes: fixes,
+		})
+	}
+
+	foundValues := map[types.Object]struct{}{}
+	foundTypes := map[types.Type]struct{}{}
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			elt = kv.Value
+		}
+		elt = ast.Unparen(elt)
+
+		if enum.TypeEnum {
+			t := pass.TypesInfo.TypeOf(elt)
+			if !enum.ContainsType(t) {
+				reportf(elt.Pos(), "implicit conversion of %v to %v", t, enum.Type)
+				continue
+			}
+			if _, exists := foundTypes[t]; exists {
+				reportf(elt.Pos(), "duplicate value %v", t)
+			}
+			foundTypes[t] = struct{}{}
+			continue
+		}
+
+		var obj types.Object
+		switch elt := elt.(type) {
+		case *ast.BasicLit:
+			reportFix(elt.Pos(), fmt.Sprintf("implicit conversion of %v to %v", elt.Value, enum.Type))
+			continue
+		case *ast.CompositeLit:
+			// reported by verifyEnumLiteral
+			continue
+		case *ast.Ident:
+			obj = memberOf(pass, pass.TypesInfo.ObjectOf(elt))
+		case *ast.SelectorExpr:
+			obj = memberOf(pass, pass.TypesInfo.ObjectOf(elt.Sel))
+		}
+		if !containsObject(enum.Values, obj) {
+			reportFix(elt.Pos(), fmt.Sprintf("invalid enum for %v", enum.Type))
+			continue
+		}
+		if _, exists := foundValues[obj]; exists {
+			reportFix(elt.Pos(), "duplicate value "+obj.Name())
+		}
+		foundValues[obj] = struct{}{}
+	}
+
+	missing := []string{}
+	if enum.TypeEnum {
+		for _, typ := range enum.Types {
+			if !isAccessibleType(pass, typ) {
+				continue
+			}
+			if _, exists := foundTypes[typ]; !exists {
+				missing = append(missing, typ.String())
+			}
+		}
+	} else {
+		for _, obj := range enum.Required() {
+			if !isAccessible(pass, obj) {
+				// unexported values cannot be listed outside of their package
+				continue
+			}
+			if _, exists := foundValues[obj]; !exists {
+				missing = append(missing, obj.Name())
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	reportFix(lit.Pos(), "missing values "+humaneList(missing))
+}
+
+// allValuesEdit replaces the elements of lit with all the accessible enum
+// values in declaration order.
+func allValuesEdit(pass *analysis.Pass, enum *enum, lit *ast.CompositeLit) analysis.TextEdit {
+	values := slices.DeleteFunc(enum.Required(), func(obj types.Object) bool {
+		return !isAccessible(pass, obj)
+	})
+	sort.SliceStable(values, func(i, k int) bool {
+		return values[i].Pos() < values[k].Pos()
+	})
+
+	qualifier := packageQualifier(pass, lit.Pos(), enum.Pkg)
+
+	var text strings.Builder
+	pos, end := lit.Lbrace+1, lit.Rbrace
+	lbrace := pass.Fset.Position(lit.Lbrace)
+	rbrace := pass.Fset.Position(lit.Rbrace)
+	if lbrace.Line == rbrace.Line || len(lit.Elts) == 0 {
+		for i, obj := range values {
+			if i > 0 {
+				text.WriteString(", ")
+			}
+			text.WriteString(qualifier + obj.Name())
+		}
+	} else {
+		// keep the comments after the opening brace
+		pos = lit.Elts[0].Pos()
+		indent := strings.Repeat("\t", pass.Fset.Position(pos).Column-1)
+		for i, obj := range values {
+			if i > 0 {
+				text.WriteString(indent)
+			}
+			text.WriteString(qualifier + obj.Name() + ",\n")
+		}
+		text.WriteString(strings.Repeat("\t", rbrace.Column-1))
+	}
+
+	return analysis.TextEdit{
+		Pos:     pos,
+		End:     end,
+		NewText: []byte(text.String()),
+	}
+}
+
+// packageQualifier returns the prefix for referring to pkg at pos.
+func packageQualifier(pass *analysis.Pass, pos token.Pos, pkg *types.Package) string {
+	if pkg == pass.Pkg {
+		return ""
+	}
+	file := fileOf(pass, pos)
+	if file == nil {
+		return pkg.Name() + "."
+	}
+	for _, spec := range file.Imports {
+		if strings.Trim(spec.Path.Value, `"`) != pkg.Path() {
+			continue
+		}
+		if spec.Name != nil {
+			return spec.Name.Name + "."
+		}
+		break
+	}
+	return pkg.Name() + "."
+}
+
+func containsObject(objs []types.Object, obj types.Object) bool {
+	for _, x := range objs {
+		if x == obj {
+			return true
+		}
+	}
+	return false
+}
+
+// fileOf returns the file that contains pos.
+func fileOf(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, file := range pass.Files {
+		if file.FileStart <= pos && pos <= file.FileEnd {
+			return file
+		}
+	}
+	return nil
+}
@@ -6,23 +6,41 @@ import (
 	"go/token"
 	"go/types"
 	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/inspector"
 )
 
+// transitionsFormat is the format for rendering the declared state machines.
+var transitionsFormat transitionsFlag
+
+func init() {
+	Analyzer.Flags.Var(&transitionsFormat, "transitions", "print declared state machines as `dot` or `mermaid`")
+}
+
 var Analyzer = &analysis.Analyzer{
 	Name: "enumcheck",
 	Doc:  "check for enum validity",
 	Run:  run,
 	Requires: []*analysis.Analyzer{
 		inspect.Analyzer,
+		ctrlflow.Analyzer,
 	},
 	FactTypes: []analysis.Fact{
 		new(packageEnumsFact),
+		new(unreachableFact),
+		new(packageMembersFact),
+		new(reexportFact),
+		new(packageErrorsFact),
+		new(returnsFact),
+		new(unionFact),
+		new(domainFact),
 	},
 }
 
@@ -36,26 +54,99 @@ func (pkg *packageEnumsFact) String() string {
 	for _, enum := range pkg.enums {
 		texts = append(texts, enum.String())
 	}
+	sort.Strings(texts)
 	return strings.Join(texts, ", ")
 }
 
 type enumSet map[types.Type]*enum
 
+// Of returns the enum for typ, including aliases and pointers to struct enums.
+func (set enumSet) Of(typ types.Type) (*enum, bool) {
+	typ = types.Unalias(typ)
+	if enum, ok := set[typ]; ok {
+		return enum, true
+	}
+	if ptr, ok := typ.(*types.Pointer); ok {
+		if enum, ok := set[types.Unalias(ptr.Elem())]; ok && enum.Pointer {
+			return enum, true
+		}
+	}
+	return nil, false
+}
+
+// declaredBy returns the enum whose values are declared by spec.
+func (set enumSet) declaredBy(pass *analysis.Pass, spec *ast.ValueSpec) *enum {
+	for _, name := range spec.Names {
+		obj := pass.TypesInfo.Defs[name]
+		if obj == nil {
+			continue
+		}
+		if enum, ok := set.Of(obj.Type()); ok && containsObject(enum.Values, obj) {
+			return enum
+		}
+	}
+	return nil
+}
+
 type enum struct {
 	Pkg      *types.Package
 	Mode     enumMode
 	Type     types.Type
 	TypeEnum bool
+	NonZero  bool
+	Strict   bool // Strict requires default clauses to fail loudly
+	Open     bool // Open allows other packages to add values and types
+	Pointer  bool // Pointer is set when values are pointers to a struct enum
 	Values   []types.Object
 	Types    []types.Type
 
+	// Sentinels are values that are not required to be handled,
+	// e.g. `numLetters` or `LetterUnknown`.
+	Sentinels []types.Object
+	// Deprecated are values that may be handled, but should not be used.
+	// It maps the value to the deprecation comment.
+	Deprecated map[types.Object]token.Pos
+
+	// Transitions are the allowed changes from one value to another.
+	Transitions map[types.Object][]types.Object
+
+	// Groups are named subsets of Values or Types.
+	Groups     map[string][]types.Object
+	TypeGroups map[string][]types.Type
+
 	ValueSpecs []*ast.ValueSpec
 }
 
+// IsDeprecated returns whether obj is a deprecated value.
+func (enum *enum) IsDeprecated(obj types.Object) bool {
+	_, ok := enum.Deprecated[obj]
+	return ok
+}
+
+// Required returns values that need to be handled.
+func (enum *enum) Required() []types.Object {
+	values := []types.Object{}
+	for _, obj := range enum.Values {
+		if !enum.IsDeprecated(obj) {
+			values = append(values, obj)
+		}
+	}
+	return values
+}
+
+// IsSentinel returns whether obj is a sentinel value.
+func (enum *enum) IsSentinel(obj types.Object) bool {
+	return containsObject(enum.Sentinels, obj)
+}
+
 func (enum *enum) ContainsType(t types.Type) bool {
 	if !enum.TypeEnum {
 		return true
 	}
+	// the interface itself and nil don't introduce new types
+	if types.Identical(t, enum.Type) || t == types.Typ[types.UntypedNil] {
+		return true
+	}
 	for _, typ := range enum.Types {
 		if typ == t {
 			return true
@@ -92,6 +183,8 @@ const (
 	modeRelaxed    enumMode = 2 // modeRelaxed, requires all values; optional default blocks
 	modeComplete   enumMode = 3 // modeComplete, requires either all values or a default block.
 	modeSilent     enumMode = 4 // modeSilent, ignore all reports
+	modeFlags      enumMode = 5 // modeFlags, values can be combined; does not require any values
+	modeClosed     enumMode = 6 // modeClosed, requires all values; forbids default blocks
 )
 
 func (mode enumMode) ShouldIgnore() bool {
@@ -112,11 +205,55 @@ func contains(tags []string, s string) bool {
 }
 
 type enumComment struct {
-	mode enumMode
+	mode    enumMode
+	modeSet bool // modeSet is true when the mode was specified
+	all     bool // all marks a list of all enum values
+
+	produces string // produces is the enum returned by a function
+
+	mapping   bool // mapping marks a conversion between two enums
+	bijective bool // bijective requires every target value to be produced once
+
+	composite bool // composite marks a flag value with multiple bits
+	sentinel  bool // sentinel marks a value that is not required to be handled
+	nonzero   bool // nonzero marks an enum whose zero value is invalid
+
+	deprecated bool // deprecated marks a value that is optional, but should not be used
+
+	groups []string // groups lists the named groups of a value
+	subset string   // subset restricts a switch to a named group
+
+	except []string // except lists values that a switch does not need to handle
+	reason string   // reason explains why the values are excepted
+
+	open        bool   // open allows other packages to add values and types
+	strict      bool   // strict requires default clauses to fail loudly
+	product     bool   // product checks a switch over combinations of enums
+	transitions string // transitions declares a state machine, e.g. `Idle -> Running`
+	errors      string // errors adds sentinel errors or error types to a named set
+	returns     string // returns is the error set returned by a function
+	union       string // union is the kind field of a tagged union struct
+	unionCase   string // unionCase is the kind value of a union payload field
+	of          string // of restricts a basic typed value to the values of an enum
+	domain      string // domain is the type enum or the types that a type switch handles
+	unreachable bool   // unreachable marks a function that handles impossible values
+
+	onlyTransitions bool // onlyTransitions is set when a transition is the only argument
+}
+
+// declaresEnum returns whether the comment on a type declares an enum.
+func (c enumComment) declaresEnum() bool {
+	// error types in error sets and unions are not enums,
+	// transitions are added to an enum declared by another comment
+	return c.errors == "" && c.union == "" && !c.onlyTransitions
 }
 
 func isEnumcheckComment(comment string) (enumComment, bool) {
 	comment = strings.TrimSpace(strings.TrimPrefix(comment, "//"))
+	// ignore trailing comments, e.g. `//enumcheck:relaxed // reason`
+	if i := strings.Index(comment, "//"); i >= 0 {
+		comment = strings.TrimSpace(comment[:i])
+	}
 	matches := comment == "enumcheck" || strings.HasPrefix(comment, "enumcheck:")
 	if !matches {
 		return enumComment{}, false
@@ -126,27 +263,134 @@ func isEnumcheckComment(comment string) (enumComment, bool) {
 	c.mode = modeExhaustive
 
 	args := strings.TrimPrefix(strings.TrimPrefix(comment, "enumcheck"), ":")
+	c.modeSet = strings.TrimSpace(args) == ""
+	c.reason, args = cutReason(args)
+	if i := strings.Index(args, "transition "); i >= 0 {
+		c.transitions = strings.TrimSpace(args[i+len("transition "):])
+		args = args[:i]
+		c.onlyTransitions = strings.TrimSpace(args) == ""
+	}
+
+	inExcept := false
 	for _, x := range strings.Split(args, ",") {
-		switch strings.TrimSpace(x) {
+		x = strings.TrimSpace(x)
+		if name, ok := strings.CutPrefix(x, "except="); ok {
+			c.except = append(c.except, name)
+			inExcept = true
+			continue
+		}
+		if name, ok := strings.CutPrefix(x, "produces "); ok {
+			c.produces = strings.TrimSpace(name)
+			continue
+		}
+		if name, ok := strings.CutPrefix(x, "errors "); ok {
+			c.errors = strings.TrimSpace(name)
+			continue
+		}
+		if name, ok := strings.CutPrefix(x, "union "); ok {
+			c.union = strings.TrimSpace(name)
+			continue
+		}
+		if name, ok := strings.CutPrefix(x, "case="); ok {
+			c.unionCase = name
+			continue
+		}
+		if name, ok := strings.CutPrefix(x, "of="); ok {
+			c.of = strings.TrimSpace(name)
+			continue
+		}
+		if name, ok := strings.CutPrefix(x, "returns "); ok {
+			c.returns = strings.TrimSpace(name)
+			continue
+		}
+		if name, ok := strings.CutPrefix(x, "group="); ok {
+			c.groups = append(c.groups, name)
+			continue
+		}
+		if name, ok := strings.CutPrefix(x, "subset="); ok {
+			c.subset = name
+			continue
+		}
+
+		if mode, domain, ok := strings.Cut(x, "="); ok {
+			switch mode {
+			case "exhaustive", "complete", "relaxed", "closed":
+				// exhaustive=proto.Expr
+				x, c.domain = mode, domain
+			}
+		}
+
+		switch x {
 		case "":
 		case "exhaustive":
-			c.mode = modeExhaustive
+			c.mode, c.modeSet = modeExhaustive, true
 		case "complete":
-			c.mode = modeComplete
+			c.mode, c.modeSet = modeComplete, true
 		case "relaxed":
-			c.mode = modeRelaxed
+			c.mode, c.modeSet = modeRelaxed, true
 		case "ignore", "silent":
-			c.mode = modeSilent
+			c.mode, c.modeSet = modeSilent, true
+		case "flags":
+			c.mode, c.modeSet = modeFlags, true
+		case "closed":
+			c.mode, c.modeSet = modeClosed, true
+		case "composite":
+			c.composite = true
+		case "sentinel":
+			c.sentinel = true
+		case "nonzero":
+			c.nonzero = true
+		case "deprecated":
+			c.deprecated = true
+		case "open":
+			c.open = true
+		case "strict":
+			c.strict = true
+		case "product":
+			c.product = true
+		case "unreachable":
+			c.unreachable = true
+		case "all":
+			c.all = true
+		case "mapping":
+			c.mapping = true
+		case "mapping=bijective":
+			c.mapping = true
+			c.bijective = true
+		default:
+			// except=Gamma,Delta
+			if inExcept {
+				c.except = append(c.except, x)
+			}
 		}
 	}
 
 	return c, true
 }
 
+// cutReason removes `reason="..."` from args.
+func cutReason(args string) (reason, rest string) {
+	i := strings.Index(args, "reason=")
+	if i < 0 {
+		return "", args
+	}
+	value := args[i+len("reason="):]
+	if quoted, err := strconv.QuotedPrefix(value); err == nil {
+		reason, _ = strconv.Unquote(quoted)
+		return reason, args[:i] + value[len(quoted):]
+	}
+	end := strings.IndexAny(value, ", ")
+	if end < 0 {
+		end = len(value)
+	}
+	return value[:end], args[:i] + value[end:]
+}
+
 func run(pass *analysis.Pass) (interface{}, error) {
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
 	pkgEnums := enumSet{}
+	collectUnreachable(pass)
 
 	addTypeSpec := func(ts *ast.TypeSpec, c enumComment) {
 		obj := pass.TypesInfo.Defs[ts.Name]
@@ -154,6 +398,9 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			Pkg:      obj.Pkg(),
 			Type:     obj.Type(),
 			TypeEnum: types.IsInterface(obj.Type()),
+			NonZero:  c.nonzero,
+			Strict:   c.strict,
+			Open:     c.open,
 			Mode:     c.mode,
 		}
 	}
@@ -167,7 +414,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		var check *enumComment
 		if gd.Doc != nil {
 			for _, c := range gd.Doc.List {
-				if c, ok := isEnumcheckComment(c.Text); ok {
+				if c, ok := isEnumcheckComment(c.Text); ok && c.declaresEnum() {
 					check = &c
 					break
 				}
@@ -188,7 +435,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 
 			if ts.Doc != nil {
 				for _, c := range ts.Doc.List {
-					if c, ok := isEnumcheckComment(c.Text); ok {
+					if c, ok := isEnumcheckComment(c.Text); ok && c.declaresEnum() {
 						addTypeSpec(ts, c)
 						continue nextSpec
 					}
@@ -197,7 +444,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 
 			if ts.Comment != nil {
 				for _, c := range ts.Comment.List {
-					if c, ok := isEnumcheckComment(c.Text); ok {
+					if c, ok := isEnumcheckComment(c.Text); ok && c.declaresEnum() {
 						addTypeSpec(ts, c)
 						continue nextSpec
 					}
@@ -210,9 +457,21 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	for _, name := range scope.Names() {
 		obj := scope.Lookup(name)
 		typ := obj.Type()
-		enum, check := pkgEnums[typ]
+		enum, check := pkgEnums.Of(typ)
 		if !check {
-			continue
+			// var True = &Option{"true"}
+			ptr, ok := typ.(*types.Pointer)
+			if !ok {
+				continue
+			}
+			enum, check = pkgEnums[ptr.Elem()]
+			if !check {
+				continue
+			}
+			if _, isStruct := enum.Type.Underlying().(*types.Struct); !isStruct {
+				continue
+			}
+			enum.Pointer = true
 		}
 
 		switch obj.(type) {
@@ -223,6 +482,10 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		}
 	}
 
+	if protobufEnums {
+		collectProtobuf(pass, pkgEnums)
+	}
+
 	for _, file := range pass.Files {
 		for _, decl := range file.Decls {
 			switch decl := decl.(type) {
@@ -231,11 +494,18 @@ func run(pass *analysis.Pass) (interface{}, error) {
 					switch spec := spec.(type) {
 					case *ast.ValueSpec:
 						typ := pass.TypesInfo.TypeOf(spec.Type)
-						enum, check := pkgEnums[typ]
+						enum, check := pkgEnums.Of(typ)
 						if !check {
+							// var True = Option{"true"}
+							if enum := pkgEnums.declaredBy(pass, spec); enum != nil {
+								enum.ValueSpecs = append(enum.ValueSpecs, spec)
+							}
 							continue
 						}
 						enum.ValueSpecs = append(enum.ValueSpecs, spec)
+						if !enum.TypeEnum {
+							continue
+						}
 
 						for _, value := range spec.Values {
 							typ := pass.TypesInfo.TypeOf(value)
@@ -247,6 +517,10 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		}
 	}
 
+	collectSentinels(pass, pkgEnums)
+	collectDeprecated(pass, pkgEnums)
+	collectGroups(pass, pkgEnums)
+
 	if len(pkgEnums) > 0 {
 		for _, enum := range pkgEnums {
 			sort.Slice(enum.Values, func(i, k int) bool {
@@ -271,6 +545,12 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		}
 	}
 
+	if members := collectOpenMembers(pass, enums); len(members) > 0 {
+		pass.ExportPackageFact(&packageMembersFact{members})
+	}
+	extendOpenEnums(pass, enums)
+	collectReexports(pass, enums)
+
 	type overridePos struct {
 		file *token.File
 		line int
@@ -298,12 +578,338 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	}
 
 	reportf := func(pos token.Pos, format string, args ...interface{}) {
+		reportDiagnostic(pass, checkOverride, analysis.Diagnostic{
+			Pos:     pos,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	collectTransitions(reportf, pass, pkgEnums)
+
+	if sets := collectErrorSets(pass); len(sets) > 0 {
+		pass.ExportPackageFact(&packageErrorsFact{sets})
+	}
+	var errorSets []*errorSet
+	for _, fact := range pass.AllPackageFacts() {
+		if pkgErrors, ok := fact.Fact.(*packageErrorsFact); ok {
+			errorSets = append(errorSets, pkgErrors.sets...)
+		}
+	}
+	collectReturns(reportf, pass, errorSets)
+	collectUnions(reportf, pass, enums)
+	domains := collectDomains(reportf, pass, enums)
+	if transitionsFormat != "" {
+		if err := printTransitions(string(transitionsFormat), pkgEnums); err != nil {
+			return nil, err
+		}
+	}
+
+	// switchMode returns the mode for a dispatch over enum at pos.
+	switchMode := func(pos token.Pos, enum *enum, stack []ast.Node) enumMode {
+		mode := enum.Mode
+		if mode == modeSilent || mode == modeComplete {
+			// String, MarshalText and mappings need to handle every value
+			decl := enclosingFunc(stack)
+			if isStringer(pass, decl, enum) {
+				mode = modeRelaxed
+			}
+			if m, ok := mappingFunc(pass, enums, decl); ok && m.source == enum {
+				mode = modeRelaxed
+			}
+		}
 		if override, ok := checkOverride(pos); ok {
-			if override.mode.ShouldIgnore() {
-				return
+			switch {
+			case override.modeSet:
+				mode = override.mode
+			case override.subset != "":
+				// subset switches need to handle every value in the group
+				mode = modeRelaxed
+			case len(override.except) > 0:
+				// only waives the listed values
+			default:
+				mode = override.mode
+			}
+		}
+		return mode
+	}
+
+	// switchSubset returns the group that a switch at pos is restricted to.
+	switchSubset := func(pos token.Pos, enum *enum) (string, []types.Object, bool) {
+		override, ok := checkOverride(pos)
+		if !ok || override.subset == "" {
+			return "", nil, true
+		}
+		group, ok := enum.Groups[override.subset]
+		if !ok {
+			reportf(pos, "unknown group %v for %v", override.subset, enum.Type)
+			return "", nil, false
+		}
+		return override.subset, group, true
+	}
+
+	// switchTypeSubset returns the group that a type switch at pos is restricted to.
+	switchTypeSubset := func(pos token.Pos, enum *enum) (string, []types.Type, bool) {
+		override, ok := checkOverride(pos)
+		if !ok || override.subset == "" {
+			return "", nil, true
+		}
+		group, ok := enum.TypeGroups[override.subset]
+		if !ok {
+			reportf(pos, "unknown group %v for %v", override.subset, enum.Type)
+			return "", nil, false
+		}
+		return override.subset, group, true
+	}
+
+	// switchExcept returns the values that a switch at pos does not need to handle.
+	switchExcept := func(pos token.Pos, enum *enum, foundValues map[types.Object]struct{}) map[types.Object]bool {
+		override, ok := checkOverride(pos)
+		if !ok || len(override.except) == 0 {
+			return nil
+		}
+		if override.reason == "" {
+			reportf(pos, "except requires a reason")
+		}
+
+		excepted := map[types.Object]bool{}
+		for _, name := range override.except {
+			name := name[strings.LastIndex(name, ".")+1:]
+
+			var obj types.Object
+			for _, value := range enum.Values {
+				if value.Name() == name {
+					obj = value
+					break
+				}
+			}
+
+			if obj == nil {
+				reportf(pos, "excepted value %v is not a member of %v", name, enum.Type)
+				continue
+			}
+			if _, handled := foundValues[obj]; handled {
+				reportf(pos, "excepted value %v is handled", name)
+				continue
+			}
+			excepted[obj] = true
+		}
+		return excepted
+	}
+
+	// switchDomain returns the enum that a type switch at pos over typ
+	// is restricted to by `//enumcheck:exhaustive=Enum`.
+	switchDomain := func(pos token.Pos, typ types.Type) (*enum, bool) {
+		override, ok := checkOverride(pos)
+		if !ok || override.domain == "" {
+			return nil, false
+		}
+		return typeDomain(reportf, pass, enums, pos, typ, override)
+	}
+
+	// checkValueCases verifies options of a dispatch over a value enum,
+	// it returns whether all the values are handled. Error sets also contain
+	// types, which are handled by foundTypes.
+	checkValueCases := func(pos token.Pos, enum *enum, typ types.Type, options []ast.Expr, foundTypes []types.Type, foundDefault bool, mode enumMode) bool {
+		subset, group, ok := switchSubset(pos, enum)
+		if !ok {
+			return false
+		}
+		unexpected := func(option ast.Expr, obj types.Object) {
+			if subset != "" && containsObject(enum.Values, obj) && !containsObject(group, obj) {
+				reportf(option.Pos(), "unexpected case %v, not in group %v", obj.Name(), subset)
+			}
+		}
+
+		foundValues := map[types.Object]struct{}{}
+		for _, option := range options {
+			if enum.Mode == modeFlags {
+				verifyFlagValue(reportf, pass, enum, option.Pos(), option)
+			}
+
+			switch option := option.(type) {
+			case *ast.BasicLit:
+				if enum.Mode != modeFlags {
+					reportf(option.Pos(), "implicit conversion of %v to %v", option.Value, typ)
+				}
+			case *ast.Ident:
+				obj := memberOf(pass, pass.TypesInfo.ObjectOf(option))
+				unexpected(option, obj)
+				foundValues[obj] = struct{}{}
+			case *ast.SelectorExpr:
+				obj := memberOf(pass, pass.TypesInfo.ObjectOf(option.Sel))
+				unexpected(option, obj)
+				foundValues[obj] = struct{}{}
+			case *ast.CompositeLit:
+				// reported by verifyEnumLiteral
+			case *ast.CallExpr:
+				// conversion, e.g. Letter(1)
+				if enum.Mode != modeFlags {
+					reportf(option.Pos(), "implicit conversion of %v to %v", types.ExprString(option), typ)
+				}
+			case *ast.BinaryExpr, *ast.ParenExpr:
+				if enum.Mode != modeFlags {
+					filePos := pass.Fset.Position(option.Pos())
+					fmt.Fprintf(os.Stderr, "%v: enumcheck internal error: unhandled clause type %T\n", filePos, option)
+				}
+			default:
+				filePos := pass.Fset.Position(option.Pos())
+				fmt.Fprintf(os.Stderr, "%v: enumcheck internal error: unhandled clause type %T\n", filePos, option)
+			}
+		}
+
+		excepted := switchExcept(pos, enum, foundValues)
+
+		missing := []string{}
+		hidden := false
+		for _, obj := range enum.Required() {
+			if subset != "" && !containsObject(group, obj) {
+				continue
+			}
+			if excepted[obj] {
+				continue
+			}
+			if !isAccessible(pass, obj) {
+				// unexported values can only be handled by a default
+				hidden = true
+				continue
+			}
+			if _, exists := foundValues[obj]; !exists {
+				missing = append(missing, obj.Name())
+			}
+		}
+		if !enum.TypeEnum {
+			for _, t := range enum.Types {
+				if subset != "" {
+					continue
+				}
+				if !isAccessibleType(pass, t) {
+					hidden = true
+					continue
+				}
+				if !containsType(foundTypes, t) {
+					missing = append(missing, t.String())
+				}
+			}
+		}
+
+		if mode.NeedsDefault() && !foundDefault {
+			missing = append(missing, "default")
+		}
+		if mode == modeComplete && foundDefault {
+			missing = nil
+		}
+		if mode == modeFlags {
+			missing = nil
+		}
+		if mode.ShouldIgnore() {
+			missing = nil
+		}
+
+		if !foundDefault && mode != modeFlags && !mode.ShouldIgnore() {
+			switch {
+			case hidden:
+				missing = slices.DeleteFunc(missing, func(name string) bool { return name == "default" })
+				reportf(pos, "missing default, %v has unexported values that cannot be handled outside of package %v", typ, enum.Pkg.Name())
+			case !seesAllMembers(pass, enum):
+				missing = slices.DeleteFunc(missing, func(name string) bool { return name == "default" })
+				reportf(pos, "missing default, open enum %v may have values in packages not imported here", typ)
+			}
+		}
+
+		if len(missing) > 0 {
+			reportf(pos, "missing cases %v", humaneList(missing))
+		}
+		return len(missing) == 0
+	}
+
+	// checkTypeCases verifies options of a dispatch over a type enum or
+	// a domain of types, it returns whether all the types are handled.
+	checkTypeCases := func(pos token.Pos, enum *enum, domain string, options []ast.Expr, foundDefault bool) bool {
+		subset, group, ok := switchTypeSubset(pos, enum)
+		if !ok {
+			return false
+		}
+
+		var foundTypes []types.Type
+		for _, option := range options {
+			t := pass.TypesInfo.TypeOf(option)
+			if t == nil {
+				filePos := pass.Fset.Position(option.Pos())
+				fmt.Fprintf(os.Stderr, "%v: enumcheck internal error: unhandled clause type %T\n", filePos, option)
+				continue
+			}
+
+			if !containsType(enum.Types, t) {
+				if domain != "" {
+					reportf(option.Pos(), "%v is not in the domain %v", t.String(), domain)
+				} else {
+					reportf(option.Pos(), "implicit conversion of %v to %v", t.String(), enum.Type)
+				}
+			} else if subset != "" && !containsType(group, t) {
+				reportf(option.Pos(), "unexpected case %v, not in group %v", t.String(), subset)
+			}
+
+			foundTypes = append(foundTypes, t)
+		}
+
+		missing := []string{}
+		hidden := false
+		for _, typ := range enum.Types {
+			if subset != "" && !containsType(group, typ) {
+				continue
+			}
+			if !isAccessibleType(pass, typ) {
+				// unexported types can only be handled by a default
+				hidden = true
+				continue
+			}
+			if !containsType(foundTypes, typ) {
+				missing = append(missing, typ.String())
+			}
+		}
+
+		if domain != "" {
+			// the mode of `//enumcheck:complete=Ping|Pong`
+			override, _ := checkOverride(pos)
+			if override.mode.NeedsDefault() && !foundDefault {
+				missing = append(missing, "default")
+			}
+			if override.mode == modeComplete && foundDefault {
+				missing = nil
 			}
 		}
-		pass.Reportf(pos, format, args...)
+
+		if !foundDefault {
+			switch {
+			case hidden:
+				reportf(pos, "missing default, %v has unexported types that cannot be handled outside of package %v", enum.Type, enum.Pkg.Name())
+			case !seesAllMembers(pass, enum):
+				reportf(pos, "missing default, open enum %v may have types in packages not imported here", enum.Type)
+			}
+		}
+
+		if len(missing) > 0 {
+			reportf(pos, "missing cases %v", humaneList(missing))
+		}
+		return len(missing) == 0
+	}
+
+	// checkChain verifies an if/else-if chain or a switch without a tag,
+	// it returns whether all the values are handled.
+	checkChain := func(chain *chain, stack []ast.Node) bool {
+		if chain.typeSwitch {
+			return checkTypeCases(chain.pos, chain.enum, "", chain.options, chain.foundDefault)
+		}
+		mode := switchMode(chain.pos, chain.enum, stack)
+		return checkValueCases(chain.pos, chain.enum, chain.enum.Type, chain.options, chain.types, chain.foundDefault, mode)
+	}
+
+	// checkStrict verifies the default clause or the final else of a
+	// dispatch over a strict enum.
+	checkStrict := func(n ast.Stmt, enum *enum, stack []ast.Node) {
+		if switchMode(n.Pos(), enum, stack) == modeExhaustive && isStrict(checkOverride, enum, n.Pos()) {
+			verifyStrictDefault(reportf, pass, n, stack)
+		}
 	}
 
 	// disallow basic literal declarations and assignments
@@ -312,19 +918,52 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		(*ast.AssignStmt)(nil),
 		(*ast.SwitchStmt)(nil),
 		(*ast.TypeSwitchStmt)(nil),
+		(*ast.IfStmt)(nil),
 		(*ast.ReturnStmt)(nil),
 		(*ast.SendStmt)(nil),
 		(*ast.CallExpr)(nil),
 	}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
 		switch n := n.(type) {
 		case *ast.SwitchStmt:
+			if n.Tag == nil {
+				chain, ok := switchChain(pass, enums, n)
+				if !ok {
+					return false
+				}
+				complete := checkChain(chain, stack)
+				if switchMode(n.Pos(), chain.enum, stack) == modeClosed && !hasHiddenValues(pass, chain.enum) {
+					verifyClosedDefault(checkOverride, pass, chain.enum, n.Body, complete, stack)
+				}
+				checkStrict(n, chain.enum, stack)
+				return false
+			}
+
 			typ := pass.TypesInfo.TypeOf(n.Tag)
-			enum, ok := enums[typ]
-			if !ok {
+			if override, ok := checkOverride(n.Pos()); ok && override.product {
+				mode := modeRelaxed
+				if override.modeSet {
+					mode = override.mode
+				}
+				verifyProduct(reportf, pass, enums, n, mode)
 				return false
 			}
 
-			foundValues := map[types.Object]struct{}{}
+			enum, ok := enums.Of(typ)
+			domain := false
+			if !ok {
+				// basic typed values annotated with `//enumcheck:of=Enum`
+				enum, ok = domains.Of(pass, n.Tag)
+				if !ok {
+					return false
+				}
+				typ, domain = enum.Type, true
+			}
+
+			var options []ast.Expr
 			foundDefault := false
 			for _, clause := range n.Body.List {
 				clause := clause.(*ast.CaseClause)
@@ -332,49 +971,25 @@ func run(pass *analysis.Pass) (interface{}, error) {
 					foundDefault = true
 					continue
 				}
-
-				for _, option := range clause.List {
-					switch option := option.(type) {
-					case *ast.BasicLit:
-						reportf(option.Pos(), "implicit conversion of %v to %v", option.Value, typ)
-					case *ast.Ident:
-						obj := pass.TypesInfo.ObjectOf(option)
-						foundValues[obj] = struct{}{}
-					case *ast.SelectorExpr:
-						obj := pass.TypesInfo.ObjectOf(option.Sel)
-						foundValues[obj] = struct{}{}
-					case *ast.CompositeLit:
-						reportf(option.Pos(), "invalid enum for %v", typ)
-					default:
-						filePos := pass.Fset.Position(option.Pos())
-						fmt.Fprintf(os.Stderr, "%v: enumcheck internal error: unhandled clause type %T\n", filePos, option)
-					}
-				}
+				options = append(options, clause.List...)
 			}
-
-			missing := []string{}
-			for _, obj := range enum.Values {
-				if _, exists := foundValues[obj]; !exists {
-					missing = append(missing, obj.Name())
-				}
+			if domain {
+				options = domainOptions(reportf, pass, enum, options)
 			}
 
-			mode := enum.Mode
-			if override, ok := checkOverride(n.Pos()); ok {
-				mode = override.mode
-			}
-			if mode.NeedsDefault() && !foundDefault {
-				missing = append(missing, "default")
-			}
-			if mode == modeComplete && foundDefault {
-				missing = nil
+			mode := switchMode(n.Pos(), enum, stack)
+			if _, overridden := checkOverride(n.Pos()); !overridden && isUnionKind(pass, n.Tag) {
+				// switches over the kind of a union need to handle every value
+				if mode == modeSilent || mode == modeComplete {
+					mode = modeRelaxed
+				}
 			}
-			if mode.ShouldIgnore() {
-				missing = nil
+			complete := checkValueCases(n.Pos(), enum, typ, options, nil, foundDefault, mode)
+			if mode == modeClosed && !hasHiddenValues(pass, enum) {
+				verifyClosedDefault(checkOverride, pass, enum, n.Body, complete, stack)
 			}
-
-			if len(missing) > 0 {
-				reportf(n.Pos(), "missing cases %v", humaneList(missing))
+			if mode == modeExhaustive && isStrict(checkOverride, enum, n.Pos()) {
+				verifyStrictDefault(reportf, pass, n, stack)
 			}
 
 		case *ast.TypeSwitchStmt:
@@ -393,52 +1008,53 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			default:
 				return false
 			}
-			enum, ok := enums[typ]
+			domain := ""
+			enum, ok := enums.Of(typ)
 			if !ok {
-				return false
+				// any restricted with `//enumcheck:exhaustive=Enum`
+				enum, ok = switchDomain(n.Pos(), typ)
+				if !ok {
+					return false
+				}
+				override, _ := checkOverride(n.Pos())
+				domain = override.domain
 			}
 
-			foundTypes := map[types.Type]struct{}{}
+			var options []ast.Expr
+			foundDefault := false
 			for _, clause := range n.Body.List {
 				clause := clause.(*ast.CaseClause)
-				for _, option := range clause.List {
-					t := pass.TypesInfo.TypeOf(option)
-					if t == nil {
-						filePos := pass.Fset.Position(option.Pos())
-						fmt.Fprintf(os.Stderr, "%v: enumcheck internal error: unhandled clause type %T\n", filePos, option)
-						continue
-					}
-
-					foundMatch := false
-					for _, typ := range enum.Types {
-						if typ == t {
-							foundMatch = true
-							break
-						}
-					}
-					if !foundMatch {
-						reportf(option.Pos(), "implicit conversion of %v to %v", t.String(), enum.Type)
-					}
-
-					foundTypes[t] = struct{}{}
+				if clause.List == nil {
+					foundDefault = true
+					continue
 				}
+				options = append(options, clause.List...)
+			}
+
+			complete := checkTypeCases(n.Pos(), enum, domain, options, foundDefault)
+			if switchMode(n.Pos(), enum, stack) == modeClosed && !hasHiddenValues(pass, enum) {
+				verifyClosedDefault(checkOverride, pass, enum, n.Body, complete, stack)
 			}
+			checkStrict(n, enum, stack)
 
-			missing := []string{}
-			for _, typ := range enum.Types {
-				if _, exists := foundTypes[typ]; !exists {
-					missing = append(missing, typ.String())
+		case *ast.IfStmt:
+			// else-if branches are handled as part of the chain
+			if len(stack) >= 2 {
+				if parent, ok := stack[len(stack)-2].(*ast.IfStmt); ok && parent.Else == n {
+					return true
 				}
 			}
 
-			if len(missing) > 0 {
-				reportf(n.Pos(), "missing cases %v", humaneList(missing))
+			if chain, ok := ifChain(pass, enums, n); ok {
+				checkChain(chain, stack)
+				checkStrict(n, chain.enum, stack)
 			}
+			return true
 
 		case *ast.ValueSpec:
 			// var x, y EnumType = 123, EnumConst
 			typ := pass.TypesInfo.TypeOf(n.Type)
-			enum, ok := enums[typ]
+			enum, ok := enums.Of(typ)
 			if !ok {
 				return false
 			}
@@ -447,6 +1063,10 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			}
 
 			for _, rhs := range n.Values {
+				if enum.Mode == modeFlags {
+					verifyFlagValue(reportf, pass, enum, n.Pos(), rhs)
+					continue
+				}
 				if basic, isBasic := rhs.(*ast.BasicLit); isBasic {
 					reportf(n.Pos(), "implicit conversion of %v to %v", basic.Value, typ)
 					return false
@@ -469,6 +1089,10 @@ func run(pass *analysis.Pass) (interface{}, error) {
 					// then type checker guarantees the assignment
 				} else {
 					rhs := n.Rhs[i]
+					if enum.Mode == modeFlags {
+						verifyFlagValue(reportf, pass, enum, n.Pos(), rhs)
+						return
+					}
 					if basic, isBasic := rhs.(*ast.BasicLit); isBasic {
 						reportf(n.Pos(), "implicit conversion of %v to %v", basic.Value, against)
 					}
@@ -487,14 +1111,14 @@ func run(pass *analysis.Pass) (interface{}, error) {
 					if obj == nil {
 						continue
 					}
-					enum, ok := enums[obj.Type()]
+					enum, ok := enums.Of(obj.Type())
 					if !ok {
 						continue
 					}
 					check(enum, obj.Type(), i)
 				case ast.Expr:
 					typ := pass.TypesInfo.TypeOf(lhs)
-					enum, ok := enums[typ]
+					enum, ok := enums.Of(typ)
 					if !ok {
 						continue
 					}
@@ -514,15 +1138,7 @@ func run(pass *analysis.Pass) (interface{}, error) {
 			}
 
 		case *ast.ReturnStmt:
-			// TODO: this probably can be optimized
-			var funcDecl *ast.FuncDecl
-			for i := len(stack) - 1; i >= 0; i-- {
-				decl, ok := stack[i].(*ast.FuncDecl)
-				if ok {
-					funcDecl = decl
-					break
-				}
-			}
+			funcDecl := enclosingFunc(stack)
 			if funcDecl == nil {
 				filePos := pass.Fset.Position(n.Pos())
 				fmt.Fprintf(os.Stderr, "%v: enumcheck internal error: unable to find func decl\n", filePos)
@@ -539,10 +1155,17 @@ func run(pass *analysis.Pass) (interface{}, error) {
 
 			returnIndex := 0
 			for _, resultField := range funcDecl.Type.Results.List {
-				for range resultField.Names {
+				count := len(resultField.Names)
+				if count == 0 {
+					// unnamed result
+					count = 1
+				}
+				for range count {
 					typ := pass.TypesInfo.TypeOf(resultField.Type)
-					enum, ok := enums[typ]
-					if ok {
+					enum, ok := enums.Of(typ)
+					if ok && enum.Mode == modeFlags {
+						verifyFlagValue(reportf, pass, enum, n.Pos(), n.Results[returnIndex])
+					} else if ok {
 						ret := n.Results[returnIndex]
 						if basic, isBasic := ret.(*ast.BasicLit); isBasic {
 							reportf(n.Pos(), "implicit conversion of %v to %v", basic.Value, enum.Type)
@@ -565,10 +1188,14 @@ func run(pass *analysis.Pass) (interface{}, error) {
 
 			switch typ := chanType.(type) {
 			case *types.Chan:
-				enum, ok := enums[typ.Elem()]
+				enum, ok := enums.Of(typ.Elem())
 				if !ok {
 					return false
 				}
+				if enum.Mode == modeFlags {
+					verifyFlagValue(reportf, pass, enum, n.Pos(), n.Value)
+					return false
+				}
 				if basic, isBasic := n.Value.(*ast.BasicLit); isBasic {
 					reportf(n.Pos(), "implicit conversion of %v to %v", basic.Value, enum.Type)
 				}
@@ -594,10 +1221,157 @@ func run(pass *analysis.Pass) (interface{}, error) {
 		return false
 	})
 
+	// check flag declarations
+	for _, enum := range pkgEnums {
+		if enum.Mode == modeFlags {
+			verifyFlagDeclaration(reportf, pass, enum)
+		}
+	}
+
+	// check declarations of nonzero enums
+	for _, enum := range pkgEnums {
+		if enum.NonZero {
+			verifyNonZeroDeclaration(reportf, pass, enum)
+		}
+	}
+
+	// check zero values of nonzero enums
+	inspect.Preorder([]ast.Node{
+		(*ast.ValueSpec)(nil),
+		(*ast.CompositeLit)(nil),
+		(*ast.CallExpr)(nil),
+	}, func(n ast.Node) {
+		verifyNonZero(reportf, pass, enums, n)
+	})
+
+	// check lists of all values
+	for _, lit := range collectAllValues(pass) {
+		verifyAllValues(reportf, checkOverride, pass, enums, lit)
+	}
+
+	// check functions that produce enum values
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok {
+				verifyProduces(reportf, pass, enums, decl)
+				verifyMapping(reportf, pass, enums, decl)
+			}
+		}
+	}
+
+	// check uses of sentinel and deprecated values
+	inspect.WithStack([]ast.Node{
+		(*ast.Ident)(nil),
+	}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if push {
+			verifySentinelUse(reportf, pass, enums, n.(*ast.Ident), stack)
+			verifyDeprecatedUse(checkOverride, pass, enums, n.(*ast.Ident), stack)
+		}
+		return true
+	})
+
+	// check modifications of enum values
+	inspect.WithStack([]ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.IncDecStmt)(nil),
+		(*ast.UnaryExpr)(nil),
+		(*ast.SelectorExpr)(nil),
+	}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if push {
+			verifyMutation(reportf, pass, enums, n, stack)
+		}
+		return true
+	})
+
+	// check dispatches over errors from error sets
+	errorVars := errorSources(pass)
+	inspect.WithStack([]ast.Node{
+		(*ast.SwitchStmt)(nil),
+		(*ast.IfStmt)(nil),
+	}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		// else-if branches are handled as part of the chain
+		if len(stack) >= 2 {
+			if parent, ok := stack[len(stack)-2].(*ast.IfStmt); ok && parent.Else == n {
+				return true
+			}
+		}
+		if chain, ok := errorChain(pass, errorVars, n); ok {
+			checkChain(chain, stack)
+		}
+		return true
+	})
+
+	// check stores to and comparisons with values annotated with `//enumcheck:of=Enum`
+	inspect.Preorder([]ast.Node{
+		(*ast.AssignStmt)(nil),
+		(*ast.IncDecStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.CompositeLit)(nil),
+		(*ast.BinaryExpr)(nil),
+		(*ast.CallExpr)(nil),
+	}, func(n ast.Node) {
+		verifyDomainUse(reportf, pass, domains, n)
+	})
+
+	// check reads of union payloads
+	inspect.WithStack([]ast.Node{
+		(*ast.SelectorExpr)(nil),
+	}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if push {
+			verifyUnionRead(reportf, pass, n.(*ast.SelectorExpr), stack)
+		}
+		return true
+	})
+
+	// check state transitions
+	inspect.Preorder([]ast.Node{
+		(*ast.SwitchStmt)(nil),
+	}, func(n ast.Node) {
+		verifyTransitions(reportf, pass, enums, n.(*ast.SwitchStmt))
+	})
+
+	// check enum values constructed outside of declarations and tables keyed by enums
+	inspect.WithStack([]ast.Node{
+		(*ast.CompositeLit)(nil),
+	}, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if push {
+			verifyEnumLiteral(reportf, pass, enums, n.(*ast.CompositeLit), stack)
+			verifyCompositeLit(reportf, checkOverride, pass, enums, n.(*ast.CompositeLit))
+			verifyUnionLiteral(reportf, pass, n.(*ast.CompositeLit))
+		}
+		return true
+	})
+
 	return nil, nil
 }
 
+// enclosingFunc returns the innermost function declaration in stack.
+func enclosingFunc(stack []ast.Node) *ast.FuncDecl {
+	// TODO: this probably can be optimized
+	for i := len(stack) - 1; i >= 0; i-- {
+		decl, ok := stack[i].(*ast.FuncDecl)
+		if ok {
+			return decl
+		}
+	}
+	return nil
+}
+
 type reportFn func(pos token.Pos, format string, args ...interface{})
+type overrideFn func(pos token.Pos) (enumComment, bool)
+
+// reportDiagnostic reports diag, unless it has been silenced on the same line.
+func reportDiagnostic(pass *analysis.Pass, checkOverride overrideFn, diag analysis.Diagnostic) {
+	if override, ok := checkOverride(diag.Pos); ok {
+		if override.mode.ShouldIgnore() {
+			return
+		}
+	}
+	pass.Report(diag)
+}
 
 func verifyCallExpr(reportf reportFn, pass *analysis.Pass, enums enumSet, n *ast.CallExpr) {
 	fn := pass.TypesInfo.TypeOf(n.Fun)
@@ -609,12 +1383,16 @@ func verifyCallExpr(reportf reportFn, pass *analysis.Pass, enums enumSet, n *ast
 	params := sig.Params()
 	for i := 0; i < params.Len(); i++ {
 		param := params.At(i)
-		enum, ok := enums[param.Type()]
+		enum, ok := enums.Of(param.Type())
 		if !ok {
 			continue
 		}
 
 		arg := n.Args[i]
+		if enum.Mode == modeFlags {
+			verifyFlagValue(reportf, pass, enum, n.Pos(), arg)
+			continue
+		}
 		if basic, isBasic := arg.(*ast.BasicLit); isBasic {
 			reportf(n.Pos(), "implicit conversion of %v to %v", basic.Value, enum.Type)
 		}
@@ -647,3 +1425,35 @@ func humaneList(list []string) string {
 
 	return s.String()
 }
+
+// isAccessible returns whether obj can be referred to from the current package.
+func isAccessible(pass *analysis.Pass, obj types.Object) bool {
+	return obj.Exported() || obj.Pkg() == pass.Pkg
+}
+
+// isAccessibleType returns whether typ can be named in the current package.
+func isAccessibleType(pass *analysis.Pass, typ types.Type) bool {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	if named, ok := typ.(*types.Named); ok {
+		return isAccessible(pass, named.Obj())
+	}
+	return true
+}
+
+// hasHiddenValues returns whether enum has values or types that cannot be
+// referred to from the current package.
+func hasHiddenValues(pass *analysis.Pass, enum *enum) bool {
+	for _, obj := range enum.Required() {
+		if !isAccessible(pass, obj) {
+			return true
+		}
+	}
+	for _, typ := range enum.Types {
+		if !isAccessibleType(pass, typ) {
+			return true
+		}
+	}
+	return false
+}
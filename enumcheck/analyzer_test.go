@@ -1,6 +1,7 @@
 package enumcheck_test
 
 import (
+	"strings"
 	"testing"
 
 	"golang.org/x/tools/go/analysis/analysistest"
@@ -11,13 +12,101 @@ import (
 func TestFromFileSystem(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, enumcheck.Analyzer,
+		"enumany",
 		"enumbyte",
+		"enumchain",
 		"enumcomplete",
+		"enumdeprecated",
+		"enumdomain",
+		"enumexcept",
+		"enumfacade",
+		"enumflags",
+		"enumgroup",
+		"enumhidden",
+		"enummapping",
+		"enummutation",
+		"enummutation/client",
+		"enumnonzero",
+		"enumopen",
+		"enumopen/app",
+		"enumopen/gzip",
+		"enumopen/lib",
+		"enumopen/plain",
+		"enumopen/zstd",
 		"enumpartial",
+		"enumpointer",
+		"enumproduct",
+		"enumreturn",
+		"enumsentinel",
+		"enumstrict",
 		"enumstring",
 		"enumstring2",
+		"enumstringer",
 		"enumstruct",
+		"enumtable",
+		"enumtransition",
 		"enumtype",
+		"enumunion",
+		"errorset",
+		"errorset/client",
 		"indirect",
 	)
 }
+
+func TestDeprecatedRelated(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, enumcheck.Analyzer, "enumdeprecated")
+
+	// lines of the deprecation comments in enumdeprecated
+	deprecatedAt := map[string]int{
+		"Beta":  15,
+		"Delta": 18,
+	}
+
+	uses := 0
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			name, ok := strings.CutPrefix(diag.Message, "use of deprecated enum value ")
+			if !ok {
+				continue
+			}
+			uses++
+			if len(diag.Related) != 1 {
+				t.Errorf("%v: expected one related information, got %d", diag.Message, len(diag.Related))
+				continue
+			}
+			related := diag.Related[0]
+			position := result.Pass.Fset.Position(related.Pos)
+			if position.Line != deprecatedAt[name] {
+				t.Errorf("%v: related information at line %d, expected %d", diag.Message, position.Line, deprecatedAt[name])
+			}
+			if want := name + " is deprecated here"; related.Message != want {
+				t.Errorf("%v: related message %q, expected %q", diag.Message, related.Message, want)
+			}
+		}
+	}
+	if uses == 0 {
+		t.Error("no uses of deprecated values were reported")
+	}
+}
+
+func TestProtobuf(t *testing.T) {
+	if err := enumcheck.Analyzer.Flags.Set("protobuf", "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer enumcheck.Analyzer.Flags.Set("protobuf", "false")
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, enumcheck.Analyzer,
+		"protoenum",
+		"protoenum/pb",
+	)
+}
+
+func TestSuggestedFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, enumcheck.Analyzer,
+		"enumall",
+		"enumclosed",
+	)
+}
@@ -0,0 +1,186 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// chain is a dispatch over an enum written as an if/else-if chain
+// or as a switch statement without a tag.
+//
+//	if x == Alpha { ... } else if x == Beta || x == Gamma { ... } else { ... }
+//	switch { case x == Alpha: ...; case x == Beta, x == Gamma: ... }
+//	if v, ok := x.(Add); ok { ... } else if v, ok := x.(Mul); ok { ... }
+type chain struct {
+	pos  token.Pos
+	enum *enum
+
+	// subject is the expression that is compared in every condition.
+	subject string
+	// typeSwitch is set when the conditions are type assertions.
+	typeSwitch bool
+
+	options      []ast.Expr
+	foundDefault bool
+
+	// types are the error types matched with errors.As in an error dispatch.
+	types []types.Type
+}
+
+// add adds a condition to the chain, it returns false when the
+// condition doesn't compare the same enum expression.
+func (chain *chain) add(pass *analysis.Pass, enums enumSet, cond ast.Expr) bool {
+	cond = ast.Unparen(cond)
+
+	if binary, ok := cond.(*ast.BinaryExpr); ok && binary.Op == token.LOR {
+		return chain.add(pass, enums, binary.X) && chain.add(pass, enums, binary.Y)
+	}
+
+	binary, ok := cond.(*ast.BinaryExpr)
+	if !ok || binary.Op != token.EQL {
+		return false
+	}
+
+	subject, value := ast.Unparen(binary.X), ast.Unparen(binary.Y)
+	if isEnumValue(pass, subject) && !isEnumValue(pass, value) {
+		subject, value = value, subject
+	}
+	if !isEnumValue(pass, value) {
+		// comparing two variables is not a dispatch
+		return false
+	}
+
+	enum, ok := enums.Of(pass.TypesInfo.TypeOf(subject))
+	if !ok || enum.TypeEnum {
+		return false
+	}
+
+	return chain.use(enum, types.ExprString(subject), false, value)
+}
+
+// addTypeAssert adds `v, ok := x.(T); ok` condition to the chain.
+func (chain *chain) addTypeAssert(pass *analysis.Pass, enums enumSet, init ast.Stmt, cond ast.Expr) bool {
+	assign, ok := init.(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 2 || len(assign.Rhs) != 1 {
+		return false
+	}
+	assert, ok := ast.Unparen(assign.Rhs[0]).(*ast.TypeAssertExpr)
+	if !ok || assert.Type == nil {
+		return false
+	}
+
+	okIdent, isIdent := assign.Lhs[1].(*ast.Ident)
+	condIdent, isCondIdent := ast.Unparen(cond).(*ast.Ident)
+	if !isIdent || !isCondIdent || okIdent.Name == "_" {
+		return false
+	}
+	if pass.TypesInfo.ObjectOf(okIdent) != pass.TypesInfo.ObjectOf(condIdent) {
+		return false
+	}
+
+	subject := ast.Unparen(assert.X)
+	enum, ok := enums.Of(pass.TypesInfo.TypeOf(subject))
+	if !ok || !enum.TypeEnum {
+		return false
+	}
+
+	return chain.use(enum, types.ExprString(subject), true, assert.Type)
+}
+
+// use adds option to the chain, when it matches the previous conditions.
+func (chain *chain) use(enum *enum, subject string, typeSwitch bool, option ast.Expr) bool {
+	if chain.enum == nil {
+		chain.enum = enum
+		chain.subject = subject
+		chain.typeSwitch = typeSwitch
+	}
+	if chain.enum != enum || chain.subject != subject || chain.typeSwitch != typeSwitch {
+		return false
+	}
+
+	chain.options = append(chain.options, option)
+	return true
+}
+
+// isEnumValue returns whether expr refers to a constant, a package level
+// variable or is a literal.
+func isEnumValue(pass *analysis.Pass, expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.BasicLit, *ast.CompositeLit:
+		return true
+	case *ast.Ident:
+		return isPackageValue(pass.TypesInfo.ObjectOf(expr))
+	case *ast.SelectorExpr:
+		return isPackageValue(pass.TypesInfo.ObjectOf(expr.Sel))
+	case *ast.CallExpr:
+		// conversion, e.g. Letter(4)
+		if len(expr.Args) == 1 {
+			if tv, ok := pass.TypesInfo.Types[expr.Fun]; ok && tv.IsType() {
+				return isEnumValue(pass, expr.Args[0])
+			}
+		}
+	}
+	return false
+}
+
+// isPackageValue returns whether obj is a constant or a package level variable.
+func isPackageValue(obj types.Object) bool {
+	switch obj := obj.(type) {
+	case *types.Const:
+		return true
+	case *types.Var:
+		return obj.Pkg() != nil && obj.Parent() == obj.Pkg().Scope()
+	}
+	return false
+}
+
+// switchChain collects the conditions of a switch statement without a tag.
+func switchChain(pass *analysis.Pass, enums enumSet, n *ast.SwitchStmt) (*chain, bool) {
+	chain := &chain{pos: n.Pos()}
+	for _, clause := range n.Body.List {
+		clause := clause.(*ast.CaseClause)
+		if clause.List == nil {
+			chain.foundDefault = true
+			continue
+		}
+		for _, cond := range clause.List {
+			if !chain.add(pass, enums, cond) {
+				return nil, false
+			}
+		}
+	}
+	return chain, chain.enum != nil
+}
+
+// ifChain collects the conditions of an if/else-if chain.
+//
+// A single if statement is not considered to be a chain.
+func ifChain(pass *analysis.Pass, enums enumSet, n *ast.IfStmt) (*chain, bool) {
+	chain := &chain{pos: n.Pos()}
+
+	branches := 0
+	for stmt := ast.Stmt(n); stmt != nil; {
+		switch branch := stmt.(type) {
+		case *ast.IfStmt:
+			if branch.Init != nil {
+				if !chain.addTypeAssert(pass, enums, branch.Init, branch.Cond) {
+					return nil, false
+				}
+			} else if !chain.add(pass, enums, branch.Cond) {
+				return nil, false
+			}
+			branches++
+			stmt = branch.Else
+		case *ast.BlockStmt:
+			chain.foundDefault = true
+			stmt = nil
+		default:
+			return nil, false
+		}
+	}
+
+	return chain, branches >= 2
+}
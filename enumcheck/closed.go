@@ -0,0 +1,86 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// verifyClosedDefault reports default clauses in switches over closed enums.
+// When all the values are handled it suggests removing the default clause,
+// unless the switch may be the terminating statement of a function.
+//
+//	switch x {
+//	case Alpha, Beta:
+//	default: // error
+//	}
+func verifyClosedDefault(checkOverride overrideFn, pass *analysis.Pass, enum *enum, body *ast.BlockStmt, complete bool, stack []ast.Node) {
+	prev := body.Lbrace + 1
+	for _, stmt := range body.List {
+		clause := stmt.(*ast.CaseClause)
+		if clause.List != nil {
+			prev = clause.End()
+			continue
+		}
+
+		diag := analysis.Diagnostic{
+			Pos:     clause.Pos(),
+			Message: "closed switch over " + enum.Type.String() + " must not have a default case",
+		}
+		if complete && !isLastStmt(stack) {
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message: "Remove the default case",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     prev,
+					End:     clauseEnd(pass, clause),
+					NewText: nil,
+				}},
+			}}
+		}
+		reportDiagnostic(pass, checkOverride, diag)
+		return
+	}
+}
+
+// isLastStmt returns whether the statement at the top of stack is the last
+// statement in its block. Removing the default clause of such a switch could
+// leave the function without a terminating statement.
+func isLastStmt(stack []ast.Node) bool {
+	for i := len(stack) - 1; i >= 1; i-- {
+		var list []ast.Stmt
+		switch parent := stack[i-1].(type) {
+		case *ast.LabeledStmt:
+			continue
+		case *ast.BlockStmt:
+			list = parent.List
+		case *ast.CaseClause:
+			list = parent.Body
+		case *ast.CommClause:
+			list = parent.Body
+		default:
+			return true
+		}
+		return len(list) > 0 && list[len(list)-1] == stack[i]
+	}
+	return true
+}
+
+// clauseEnd returns the end of clause including a trailing comment on the
+// same line.
+func clauseEnd(pass *analysis.Pass, clause *ast.CaseClause) token.Pos {
+	end := clause.End()
+	file := fileOf(pass, clause.Pos())
+	if file == nil {
+		return end
+	}
+	line := pass.Fset.Position(end).Line
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			if c.Pos() >= end && pass.Fset.Position(c.Pos()).Line == line {
+				end = c.End()
+			}
+		}
+	}
+	return end
+}
@@ -0,0 +1,103 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// collectDeprecated finds values annotated with `//enumcheck:deprecated`
+// or documented with a `Deprecated:` paragraph.
+//
+//	const (
+//		Alpha Letter = iota
+//		// Deprecated: use Alpha instead.
+//		OldAlpha
+//	)
+func collectDeprecated(pass *analysis.Pass, pkgEnums enumSet) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || (decl.Tok != token.CONST && decl.Tok != token.VAR) {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.ValueSpec)
+
+				pos := deprecationComment(spec.Doc)
+				if !pos.IsValid() {
+					pos = deprecationComment(spec.Comment)
+				}
+				if !pos.IsValid() && len(decl.Specs) == 1 {
+					pos = deprecationComment(decl.Doc)
+				}
+				if !pos.IsValid() {
+					continue
+				}
+
+				for _, name := range spec.Names {
+					obj := pass.TypesInfo.Defs[name]
+					if obj == nil {
+						continue
+					}
+					enum, ok := pkgEnums.Of(obj.Type())
+					if !ok || !containsObject(enum.Values, obj) {
+						continue
+					}
+					if enum.Deprecated == nil {
+						enum.Deprecated = map[types.Object]token.Pos{}
+					}
+					enum.Deprecated[obj] = pos
+				}
+			}
+		}
+	}
+}
+
+// deprecationComment returns the position of the deprecation comment in group.
+func deprecationComment(group *ast.CommentGroup) token.Pos {
+	if group == nil {
+		return token.NoPos
+	}
+	for _, c := range group.List {
+		if x, ok := isEnumcheckComment(c.Text); ok && x.deprecated {
+			return c.Pos()
+		}
+		text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if strings.HasPrefix(text, "Deprecated:") {
+			return c.Pos()
+		}
+	}
+	return token.NoPos
+}
+
+// verifyDeprecatedUse reports deprecated values that are assigned,
+// returned or passed as an argument. Deprecated values can be still
+// used in case clauses and comparisons.
+func verifyDeprecatedUse(checkOverride overrideFn, pass *analysis.Pass, enums enumSet, ident *ast.Ident, stack []ast.Node) {
+	obj := memberOf(pass, pass.TypesInfo.Uses[ident])
+	if obj == nil {
+		return
+	}
+	enum, ok := enums.Of(obj.Type())
+	if !ok || !enum.IsDeprecated(obj) {
+		return
+	}
+
+	expr, parent, ok := valueUse(ident, stack)
+	if !ok || !isValueUse(pass, expr, parent, false) {
+		return
+	}
+
+	reportDiagnostic(pass, checkOverride, analysis.Diagnostic{
+		Pos:     expr.Pos(),
+		Message: "use of deprecated enum value " + obj.Name(),
+		Related: []analysis.RelatedInformation{{
+			Pos:     enum.Deprecated[obj],
+			Message: obj.Name() + " is deprecated here",
+		}},
+	})
+}
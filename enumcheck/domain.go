@@ -0,0 +1,446 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// domainFact marks a basic typed field, variable or parameter that only
+// holds values of an enum.
+//
+//	type Job struct {
+//		state atomic.Int32 //enumcheck:of=Phase
+//	}
+type domainFact struct {
+	enum *enum
+}
+
+func (*domainFact) AFact() {}
+func (fact *domainFact) String() string {
+	return "of " + fact.enum.Type.String()
+}
+
+// domainSet contains the enums of annotated objects in the current package.
+type domainSet map[types.Object]*enum
+
+// collectDomains finds fields, variables and parameters annotated with
+// `//enumcheck:of=Enum`.
+//
+// Parameters are annotated in the doc comment of the function, e.g.
+// `//enumcheck:of=Phase from to`.
+func collectDomains(reportf reportFn, pass *analysis.Pass, enums enumSet) domainSet {
+	domains := domainSet{}
+
+	add := func(c *ast.Comment, name string, idents []*ast.Ident, export bool) {
+		enum, ok := lookupEnum(pass, enums, name)
+		if !ok {
+			reportf(c.Pos(), "unknown enum %v", name)
+			return
+		}
+		for _, ident := range idents {
+			obj := pass.TypesInfo.Defs[ident]
+			if obj == nil || ident.Name == "_" {
+				continue
+			}
+			domains[obj] = enum
+			// local variables are only visible in this package
+			if export || obj.Parent() == pass.Pkg.Scope() {
+				pass.ExportObjectFact(obj, &domainFact{enum})
+			}
+		}
+	}
+	addGroups := func(idents []*ast.Ident, export bool, groups ...*ast.CommentGroup) {
+		for _, group := range groups {
+			if group == nil {
+				continue
+			}
+			for _, c := range group.List {
+				if x, ok := isEnumcheckComment(c.Text); ok && x.of != "" {
+					name, _, _ := strings.Cut(x.of, " ")
+					add(c, name, idents, export)
+					return
+				}
+			}
+		}
+	}
+
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.GenDecl:
+				if n.Tok != token.VAR {
+					return true
+				}
+				for _, spec := range n.Specs {
+					spec := spec.(*ast.ValueSpec)
+					if len(n.Specs) == 1 {
+						addGroups(spec.Names, false, spec.Doc, spec.Comment, n.Doc)
+					} else {
+						addGroups(spec.Names, false, spec.Doc, spec.Comment)
+					}
+				}
+			case *ast.StructType:
+				for _, field := range n.Fields.List {
+					addGroups(field.Names, true, field.Doc, field.Comment)
+				}
+			case *ast.FuncDecl:
+				if n.Doc == nil {
+					return true
+				}
+				params := map[string]*ast.Ident{}
+				for _, field := range n.Type.Params.List {
+					for _, ident := range field.Names {
+						params[ident.Name] = ident
+					}
+				}
+				for _, c := range n.Doc.List {
+					x, ok := isEnumcheckComment(c.Text)
+					if !ok || x.of == "" {
+						continue
+					}
+					names := strings.Fields(x.of)
+					var idents []*ast.Ident
+					for _, name := range names[1:] {
+						ident, ok := params[name]
+						if !ok {
+							reportf(c.Pos(), "unknown parameter %v", name)
+							continue
+						}
+						idents = append(idents, ident)
+					}
+					add(c, names[0], idents, true)
+				}
+			}
+			return true
+		})
+	}
+	return domains
+}
+
+// lookupEnum finds an enum by name, e.g. `Letter` or `pkg.Letter`.
+func lookupEnum(pass *analysis.Pass, enums enumSet, name string) (*enum, bool) {
+	for _, enum := range sortedEnums(enums) {
+		named, ok := enum.Type.(*types.Named)
+		if !ok {
+			continue
+		}
+		obj := named.Obj()
+		if obj.Name() == name && obj.Pkg() == pass.Pkg || obj.Pkg().Name()+"."+obj.Name() == name {
+			return enum, true
+		}
+	}
+	return nil, false
+}
+
+// lookup returns the enum of an annotated object.
+func (domains domainSet) lookup(pass *analysis.Pass, obj types.Object) (*enum, bool) {
+	if obj == nil {
+		return nil, false
+	}
+	if enum, ok := domains[obj]; ok {
+		return enum, true
+	}
+	if obj.Pkg() == nil || obj.Pkg() == pass.Pkg {
+		return nil, false
+	}
+	var fact domainFact
+	if !pass.ImportObjectFact(obj, &fact) {
+		return nil, false
+	}
+	return fact.enum, true
+}
+
+// Of returns the enum of an annotated object or of a load from an
+// annotated atomic.
+func (domains domainSet) Of(pass *analysis.Pass, expr ast.Expr) (*enum, bool) {
+	switch expr := unconvert(pass, expr).(type) {
+	case *ast.Ident:
+		return domains.lookup(pass, pass.TypesInfo.ObjectOf(expr))
+	case *ast.SelectorExpr:
+		return domains.lookup(pass, pass.TypesInfo.ObjectOf(expr.Sel))
+	case *ast.CallExpr:
+		fn := calledFunc(pass, expr)
+		if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "sync/atomic" {
+			return nil, false
+		}
+		// state.Load()
+		if sel, ok := ast.Unparen(expr.Fun).(*ast.SelectorExpr); ok && fn.Name() == "Load" && isMethod(fn) {
+			return domains.Of(pass, sel.X)
+		}
+		// atomic.LoadInt32(&state)
+		if strings.HasPrefix(fn.Name(), "Load") && len(expr.Args) == 1 {
+			return domains.Of(pass, unaddress(expr.Args[0]))
+		}
+	}
+	return nil, false
+}
+
+// isValue returns whether expr is a value of enum, a conversion of it or
+// another annotated value of the same enum.
+func (domains domainSet) isValue(pass *analysis.Pass, enum *enum, expr ast.Expr) bool {
+	expr = unconvert(pass, expr)
+	if obj := valueObject(pass, expr); obj != nil && containsObject(enum.Values, obj) {
+		return true
+	}
+	if typ := pass.TypesInfo.TypeOf(expr); typ != nil && types.Identical(typ, enum.Type) {
+		return true
+	}
+	other, ok := domains.Of(pass, expr)
+	return ok && other == enum
+}
+
+// verifyDomainUse checks that stores to and comparisons with annotated
+// values use the values of the enum.
+//
+//	job.state.Store(int32(Running)) // ok
+//	job.state.Store(3)              // error
+func verifyDomainUse(reportf reportFn, pass *analysis.Pass, domains domainSet, n ast.Node) {
+	check := func(enum *enum, expr ast.Expr) {
+		if !domains.isValue(pass, enum, expr) {
+			reportf(expr.Pos(), "%v is not a value of %v", types.ExprString(expr), enum.Type)
+		}
+	}
+
+	// arithmetic can produce values outside of the enum
+	arithmetic := func(enum *enum, expr ast.Expr) {
+		reportf(expr.Pos(), "arithmetic on %v, which holds values of %v", types.ExprString(expr), enum.Type)
+	}
+
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		if n.Tok != token.ASSIGN && n.Tok != token.DEFINE {
+			// m.Kind += 1
+			for _, lhs := range n.Lhs {
+				if enum, ok := domains.Of(pass, lhs); ok {
+					arithmetic(enum, lhs)
+				}
+			}
+			return
+		}
+		if n.Tok != token.ASSIGN || len(n.Lhs) != len(n.Rhs) {
+			return
+		}
+		for i, lhs := range n.Lhs {
+			if enum, ok := domains.Of(pass, lhs); ok {
+				check(enum, n.Rhs[i])
+			}
+		}
+
+	case *ast.IncDecStmt:
+		if enum, ok := domains.Of(pass, n.X); ok {
+			arithmetic(enum, n.X)
+		}
+
+	case *ast.ValueSpec:
+		if len(n.Names) != len(n.Values) {
+			return
+		}
+		for i, name := range n.Names {
+			if enum, ok := domains.lookup(pass, pass.TypesInfo.Defs[name]); ok {
+				check(enum, n.Values[i])
+			}
+		}
+
+	case *ast.CompositeLit:
+		typ := pass.TypesInfo.TypeOf(n)
+		if typ == nil {
+			return
+		}
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		structType, ok := typ.Underlying().(*types.Struct)
+		if !ok {
+			return
+		}
+		for i, elt := range n.Elts {
+			var field types.Object
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if key, ok := kv.Key.(*ast.Ident); ok {
+					field = pass.TypesInfo.ObjectOf(key)
+				}
+				elt = kv.Value
+			} else if i < structType.NumFields() {
+				field = structType.Field(i)
+			}
+			if enum, ok := domains.lookup(pass, field); ok {
+				check(enum, elt)
+			}
+		}
+
+	case *ast.BinaryExpr:
+		if n.Op != token.EQL && n.Op != token.NEQ {
+			return
+		}
+		if enum, ok := domains.Of(pass, n.X); ok {
+			check(enum, n.Y)
+		} else if enum, ok := domains.Of(pass, n.Y); ok {
+			check(enum, n.X)
+		}
+
+	case *ast.CallExpr:
+		fn := calledFunc(pass, n)
+		if fn == nil {
+			return
+		}
+		if fn.Pkg() != nil && fn.Pkg().Path() == "sync/atomic" {
+			var enum *enum
+			var target ast.Expr
+			var args []ast.Expr
+			if sel, ok := ast.Unparen(n.Fun).(*ast.SelectorExpr); ok && isMethod(fn) {
+				// state.Store(v), state.Swap(v), state.CompareAndSwap(old, new)
+				target = sel.X
+				args = n.Args
+			} else if len(n.Args) > 0 {
+				// atomic.StoreInt32(&state, v)
+				target = unaddress(n.Args[0])
+				args = n.Args[1:]
+			}
+			if target != nil {
+				enum, _ = domains.Of(pass, target)
+			}
+			if enum == nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(fn.Name(), "Store"),
+				strings.HasPrefix(fn.Name(), "Swap"),
+				strings.HasPrefix(fn.Name(), "CompareAndSwap"):
+				for _, arg := range args {
+					check(enum, arg)
+				}
+			case strings.HasPrefix(fn.Name(), "Add"),
+				strings.HasPrefix(fn.Name(), "And"),
+				strings.HasPrefix(fn.Name(), "Or"):
+				// state.Add(1), atomic.AddInt32(&state, 1)
+				arithmetic(enum, target)
+			}
+			return
+		}
+
+		sig, ok := fn.Type().(*types.Signature)
+		if !ok {
+			return
+		}
+		params := sig.Params()
+		for i := 0; i < params.Len() && i < len(n.Args); i++ {
+			if sig.Variadic() && i == params.Len()-1 {
+				break
+			}
+			if enum, ok := domains.lookup(pass, params.At(i)); ok {
+				check(enum, n.Args[i])
+			}
+		}
+	}
+}
+
+// domainOptions returns the enum values of switch options over an
+// annotated value, e.g. `case int32(Alpha):`.
+func domainOptions(reportf reportFn, pass *analysis.Pass, enum *enum, options []ast.Expr) []ast.Expr {
+	var values []ast.Expr
+	for _, option := range options {
+		value := unconvert(pass, option)
+		if _, ok := value.(*ast.BasicLit); ok {
+			// reported as an implicit conversion
+			values = append(values, value)
+			continue
+		}
+		if obj := valueObject(pass, value); obj == nil || !containsObject(enum.Values, obj) {
+			reportf(option.Pos(), "%v is not a value of %v", types.ExprString(option), enum.Type)
+			continue
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// unconvert removes conversions, e.g. `int32(Alpha)`.
+func unconvert(pass *analysis.Pass, expr ast.Expr) ast.Expr {
+	for {
+		expr = ast.Unparen(expr)
+		call, ok := expr.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 {
+			return expr
+		}
+		if tv, ok := pass.TypesInfo.Types[call.Fun]; !ok || !tv.IsType() {
+			return expr
+		}
+		expr = call.Args[0]
+	}
+}
+
+// unaddress removes the address operator, e.g. `&state`.
+func unaddress(expr ast.Expr) ast.Expr {
+	if unary, ok := ast.Unparen(expr).(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		return unary.X
+	}
+	return expr
+}
+
+func isMethod(fn *types.Func) bool {
+	sig, ok := fn.Type().(*types.Signature)
+	return ok && sig.Recv() != nil
+}
+
+// typeDomain returns the type enum named by `//enumcheck:exhaustive=Expr`
+// or an enum of the listed types, e.g. `//enumcheck:exhaustive=*Add|*Mul`.
+func typeDomain(reportf reportFn, pass *analysis.Pass, enums enumSet, pos token.Pos, typ types.Type, override enumComment) (*enum, bool) {
+	if enum, ok := lookupEnum(pass, enums, override.domain); ok {
+		if !enum.TypeEnum {
+			reportf(pos, "%v is not a type enum", enum.Type)
+			return nil, false
+		}
+		return enum, true
+	}
+
+	domain := &enum{
+		Pkg:      pass.Pkg,
+		Mode:     override.mode,
+		Type:     typ,
+		TypeEnum: true,
+	}
+	for _, name := range strings.Split(override.domain, "|") {
+		name = strings.TrimSpace(name)
+		t, ok := lookupType(pass, name)
+		if !ok {
+			reportf(pos, "unknown type %v", name)
+			continue
+		}
+		if !types.AssignableTo(t, typ) {
+			reportf(pos, "%v does not implement %v", t, typ)
+			continue
+		}
+		domain.Types = append(domain.Types, t)
+	}
+	return domain, len(domain.Types) > 0
+}
+
+// lookupType finds a type by name, e.g. `Add`, `*Add` or `*pkg.Add`.
+func lookupType(pass *analysis.Pass, name string) (types.Type, bool) {
+	name, pointer := strings.CutPrefix(name, "*")
+
+	var obj types.Object
+	if pkgName, typeName, ok := strings.Cut(name, "."); ok {
+		for _, imp := range pass.Pkg.Imports() {
+			if imp.Name() == pkgName {
+				obj = imp.Scope().Lookup(typeName)
+				break
+			}
+		}
+	} else {
+		obj = pass.Pkg.Scope().Lookup(name)
+	}
+
+	typeName, ok := obj.(*types.TypeName)
+	if !ok {
+		return nil, false
+	}
+	if pointer {
+		return types.NewPointer(typeName.Type()), true
+	}
+	return typeName.Type(), true
+}
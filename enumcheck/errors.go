@@ -0,0 +1,458 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// errorSet is a named set of sentinel errors and error types that a
+// function may return.
+type errorSet struct {
+	Pkg    *types.Package
+	Name   string
+	Values []types.Object
+	Types  []types.Type
+	Mode   enumMode
+
+	// Enum are the values and types as an enum for checking dispatches.
+	Enum *enum
+}
+
+func (set *errorSet) String() string {
+	names := []string{}
+	for _, obj := range set.Values {
+		names = append(names, obj.Name())
+	}
+	for _, typ := range set.Types {
+		names = append(names, types.TypeString(typ, types.RelativeTo(set.Pkg)))
+	}
+	return set.Pkg.Name() + "." + set.Name + " = {" + strings.Join(names, " | ") + "}"
+}
+
+// packageErrorsFact lists the error sets declared in a package.
+type packageErrorsFact struct {
+	sets []*errorSet
+}
+
+func (*packageErrorsFact) AFact() {}
+func (pkg *packageErrorsFact) String() string {
+	texts := []string{}
+	for _, set := range pkg.sets {
+		texts = append(texts, set.String())
+	}
+	return strings.Join(texts, ", ")
+}
+
+// returnsFact marks a function annotated with `//enumcheck:returns Set`.
+type returnsFact struct {
+	set *errorSet
+}
+
+func (*returnsFact) AFact() {}
+func (fact *returnsFact) String() string {
+	return "returns " + fact.set.Pkg.Name() + "." + fact.set.Name
+}
+
+// collectErrorSets finds sentinel errors and error types annotated with
+// `//enumcheck:errors Set`.
+//
+//	//enumcheck:errors Lookup
+//	var (
+//		ErrNotFound = errors.New("not found")
+//		ErrTimeout  = errors.New("timeout")
+//	)
+//
+//	//enumcheck:errors Lookup
+//	type QueryError struct{ Query string }
+//
+// Dispatches over the errors are complete by default, a set can use another
+// mode with e.g. `//enumcheck:errors Lookup,relaxed`.
+func collectErrorSets(pass *analysis.Pass) []*errorSet {
+	var sets []*errorSet
+	setOf := func(c enumComment) *errorSet {
+		var set *errorSet
+		for _, x := range sets {
+			if x.Name == c.errors {
+				set = x
+				break
+			}
+		}
+		if set == nil {
+			set = &errorSet{Pkg: pass.Pkg, Name: c.errors, Mode: modeComplete}
+			sets = append(sets, set)
+		}
+		if c.modeSet {
+			set.Mode = c.mode
+		}
+		return set
+	}
+
+	// specComment returns the comment of a spec, falling back to the
+	// comment of the declaration.
+	specComment := func(decl *ast.GenDecl, doc, comment *ast.CommentGroup) (enumComment, bool) {
+		for _, group := range []*ast.CommentGroup{comment, doc, decl.Doc} {
+			if c, ok := errorSetComment(group); ok {
+				return c, true
+			}
+		}
+		return enumComment{}, false
+	}
+
+	errorType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.ValueSpec:
+					c, ok := specComment(decl, spec.Doc, spec.Comment)
+					if !ok {
+						continue
+					}
+					set := setOf(c)
+					for _, ident := range spec.Names {
+						obj := pass.TypesInfo.Defs[ident]
+						if obj == nil || ident.Name == "_" || !types.Implements(obj.Type(), errorType) {
+							continue
+						}
+						set.Values = append(set.Values, obj)
+					}
+				case *ast.TypeSpec:
+					c, ok := specComment(decl, spec.Doc, spec.Comment)
+					if !ok {
+						continue
+					}
+					obj := pass.TypesInfo.Defs[spec.Name]
+					if obj == nil {
+						continue
+					}
+					typ := obj.Type()
+					if !types.Implements(typ, errorType) {
+						typ = types.NewPointer(typ)
+					}
+					set := setOf(c)
+					set.Types = append(set.Types, typ)
+				}
+			}
+		}
+	}
+
+	for _, set := range sets {
+		sort.Slice(set.Values, func(i, k int) bool {
+			return set.Values[i].Name() < set.Values[k].Name()
+		})
+		sort.Slice(set.Types, func(i, k int) bool {
+			return set.Types[i].String() < set.Types[k].String()
+		})
+		set.Enum = &enum{
+			Pkg:    set.Pkg,
+			Type:   types.Universe.Lookup("error").Type(),
+			Values: set.Values,
+			Types:  set.Types,
+			Mode:   set.Mode,
+		}
+	}
+	return sets
+}
+
+func errorSetComment(group *ast.CommentGroup) (enumComment, bool) {
+	if group == nil {
+		return enumComment{}, false
+	}
+	for _, c := range group.List {
+		if c, ok := isEnumcheckComment(c.Text); ok && c.errors != "" {
+			return c, true
+		}
+	}
+	return enumComment{}, false
+}
+
+// collectReturns exports facts for functions annotated with
+// `//enumcheck:returns Set`.
+func collectReturns(reportf reportFn, pass *analysis.Pass, sets []*errorSet) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.FuncDecl)
+			if !ok || decl.Doc == nil {
+				continue
+			}
+			for _, c := range decl.Doc.List {
+				x, ok := isEnumcheckComment(c.Text)
+				if !ok || x.returns == "" {
+					continue
+				}
+
+				var found *errorSet
+				for _, set := range sets {
+					if set.Name == x.returns && set.Pkg == pass.Pkg || set.Pkg.Name()+"."+set.Name == x.returns {
+						found = set
+						break
+					}
+				}
+				if found == nil {
+					reportf(c.Pos(), "unknown error set %v", x.returns)
+					break
+				}
+				if obj := pass.TypesInfo.Defs[decl.Name]; obj != nil {
+					pass.ExportObjectFact(obj, &returnsFact{found})
+				}
+				break
+			}
+		}
+	}
+}
+
+// errorSources finds variables that hold errors returned by functions
+// annotated with `//enumcheck:returns Set`.
+//
+// Variables that are assigned from different sources are ignored.
+func errorSources(pass *analysis.Pass) map[types.Object]*errorSet {
+	sources := map[types.Object]*errorSet{}
+	ambiguous := map[types.Object]bool{}
+
+	assign := func(lhs, rhs []ast.Expr) {
+		var set *errorSet
+		if len(rhs) == 1 {
+			if call, ok := ast.Unparen(rhs[0]).(*ast.CallExpr); ok {
+				set = returnedErrors(pass, call)
+			}
+		}
+		for i, expr := range lhs {
+			ident, ok := expr.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			obj := pass.TypesInfo.ObjectOf(ident)
+			if obj == nil || !isErrorType(obj.Type()) {
+				continue
+			}
+			if set == nil || i != len(lhs)-1 {
+				ambiguous[obj] = true
+				continue
+			}
+			if previous, ok := sources[obj]; ok && previous != set {
+				ambiguous[obj] = true
+			}
+			sources[obj] = set
+		}
+	}
+
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				assign(n.Lhs, n.Rhs)
+			case *ast.ValueSpec:
+				lhs := make([]ast.Expr, len(n.Names))
+				for i, name := range n.Names {
+					lhs[i] = name
+				}
+				if len(n.Values) > 0 {
+					assign(lhs, n.Values)
+				}
+			}
+			return true
+		})
+	}
+
+	for obj := range ambiguous {
+		delete(sources, obj)
+	}
+	return sources
+}
+
+// returnedErrors returns the error set of the called function.
+func returnedErrors(pass *analysis.Pass, call *ast.CallExpr) *errorSet {
+	obj := calledFunc(pass, call)
+	if obj == nil {
+		return nil
+	}
+	var fact returnsFact
+	if !pass.ImportObjectFact(obj, &fact) {
+		return nil
+	}
+	return fact.set
+}
+
+func isErrorType(typ types.Type) bool {
+	return types.Identical(typ, types.Universe.Lookup("error").Type())
+}
+
+// errorDispatch is a dispatch over an error from an error set.
+//
+//	switch {
+//	case errors.Is(err, ErrNotFound):
+//	case errors.As(err, &queryErr):
+//	case err != nil:
+//	}
+type errorDispatch struct {
+	subject types.Object
+
+	options      []ast.Expr
+	types        []types.Type
+	foundDefault bool
+}
+
+// add adds a condition to the dispatch, it returns false when the condition
+// doesn't check the same error.
+func (dispatch *errorDispatch) add(pass *analysis.Pass, cond ast.Expr) bool {
+	cond = ast.Unparen(cond)
+
+	switch cond := cond.(type) {
+	case *ast.BinaryExpr:
+		switch cond.Op {
+		case token.LOR:
+			return dispatch.add(pass, cond.X) && dispatch.add(pass, cond.Y)
+		case token.EQL:
+			if dispatch.use(pass, cond.X) {
+				return dispatch.addValue(pass, cond.Y)
+			}
+			if dispatch.use(pass, cond.Y) {
+				return dispatch.addValue(pass, cond.X)
+			}
+		case token.NEQ:
+			// err != nil handles the rest of the errors
+			if isNil(pass, cond.Y) && dispatch.use(pass, cond.X) ||
+				isNil(pass, cond.X) && dispatch.use(pass, cond.Y) {
+				dispatch.foundDefault = true
+				return true
+			}
+		}
+	case *ast.CallExpr:
+		fn := calledFunc(pass, cond)
+		if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "errors" || len(cond.Args) != 2 {
+			return false
+		}
+		if !dispatch.use(pass, cond.Args[0]) {
+			return false
+		}
+		switch fn.Name() {
+		case "Is":
+			return dispatch.addValue(pass, cond.Args[1])
+		case "As":
+			target, ok := pass.TypesInfo.TypeOf(cond.Args[1]).(*types.Pointer)
+			if !ok {
+				return false
+			}
+			dispatch.types = append(dispatch.types, target.Elem())
+			return true
+		}
+	}
+	return false
+}
+
+// use checks that expr refers to the subject of the dispatch.
+func (dispatch *errorDispatch) use(pass *analysis.Pass, expr ast.Expr) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	obj := pass.TypesInfo.ObjectOf(ident)
+	if dispatch.subject == nil {
+		dispatch.subject = obj
+	}
+	return obj != nil && dispatch.subject == obj
+}
+
+func (dispatch *errorDispatch) addValue(pass *analysis.Pass, expr ast.Expr) bool {
+	if valueObject(pass, expr) == nil {
+		return false
+	}
+	dispatch.options = append(dispatch.options, ast.Unparen(expr))
+	return true
+}
+
+func isNil(pass *analysis.Pass, expr ast.Expr) bool {
+	tv, ok := pass.TypesInfo.Types[expr]
+	return ok && tv.IsNil()
+}
+
+// calledFunc returns the function that call calls.
+func calledFunc(pass *analysis.Pass, call *ast.CallExpr) *types.Func {
+	switch fn := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		obj, _ := pass.TypesInfo.Uses[fn].(*types.Func)
+		return obj
+	case *ast.SelectorExpr:
+		obj, _ := pass.TypesInfo.Uses[fn.Sel].(*types.Func)
+		return obj
+	}
+	return nil
+}
+
+// errorDispatchOf collects the conditions of a switch or an if/else-if chain
+// that checks an error. A default clause, a final else and `err != nil`
+// handle the rest of the errors.
+func errorDispatchOf(pass *analysis.Pass, n ast.Node) (*errorDispatch, bool) {
+	dispatch := &errorDispatch{}
+	switch n := n.(type) {
+	case *ast.SwitchStmt:
+		if n.Tag != nil && !dispatch.use(pass, n.Tag) {
+			return nil, false
+		}
+		for _, clause := range n.Body.List {
+			clause := clause.(*ast.CaseClause)
+			if clause.List == nil {
+				dispatch.foundDefault = true
+				continue
+			}
+			for _, option := range clause.List {
+				if n.Tag != nil {
+					if !dispatch.addValue(pass, option) {
+						return nil, false
+					}
+				} else if !dispatch.add(pass, option) {
+					return nil, false
+				}
+			}
+		}
+	case *ast.IfStmt:
+		branches := 0
+		for stmt := ast.Stmt(n); stmt != nil; {
+			branch, ok := stmt.(*ast.IfStmt)
+			if !ok {
+				dispatch.foundDefault = true
+				break
+			}
+			if branch.Init != nil || !dispatch.add(pass, branch.Cond) {
+				return nil, false
+			}
+			branches++
+			stmt = branch.Else
+		}
+		if branches < 2 {
+			return nil, false
+		}
+	}
+	return dispatch, dispatch.subject != nil
+}
+
+// errorChain returns the dispatch over an error as a chain over the enum of
+// the error set of the function that returned it.
+func errorChain(pass *analysis.Pass, sources map[types.Object]*errorSet, n ast.Node) (*chain, bool) {
+	dispatch, ok := errorDispatchOf(pass, n)
+	if !ok {
+		return nil, false
+	}
+	set, ok := sources[dispatch.subject]
+	if !ok {
+		return nil, false
+	}
+	return &chain{
+		pos:          n.Pos(),
+		enum:         set.Enum,
+		subject:      dispatch.subject.Name(),
+		options:      dispatch.options,
+		types:        dispatch.types,
+		foundDefault: dispatch.foundDefault,
+	}, true
+}
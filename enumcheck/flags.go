@@ -0,0 +1,133 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// flagValue returns the bits of a constant flag value.
+func flagValue(obj types.Object) (uint64, bool) {
+	c, ok := obj.(*types.Const)
+	if !ok {
+		return 0, false
+	}
+	return constant.Uint64Val(constant.ToInt(c.Val()))
+}
+
+// flagMask returns the union of all flag values.
+func flagMask(enum *enum) uint64 {
+	var mask uint64
+	for _, obj := range enum.Values {
+		if value, ok := flagValue(obj); ok {
+			mask |= value
+		}
+	}
+	return mask
+}
+
+// verifyFlagValue checks that a constant expression only uses bits
+// defined by the flags. Non-constant expressions, such as
+// combinations of variables, are always allowed.
+//
+//	var x Perm = Read | Write // ok
+//	var x Perm = Read | 0x80  // error
+func verifyFlagValue(reportf reportFn, pass *analysis.Pass, enum *enum, pos token.Pos, expr ast.Expr) {
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil {
+		return
+	}
+	value, ok := constant.Uint64Val(constant.ToInt(tv.Value))
+	if !ok {
+		reportf(pos, "implicit conversion of %v to %v", types.ExprString(expr), enum.Type)
+		return
+	}
+
+	if value&^flagMask(enum) != 0 {
+		reportf(pos, "%v has bits outside of %v", types.ExprString(expr), enum.Type)
+	}
+}
+
+// verifyFlagDeclaration checks that every flag is a distinct single bit or
+// a composite annotated with `//enumcheck:composite`.
+//
+//	const (
+//		Read Perm = 1 << iota
+//		Write
+//
+//		ReadWrite = Read | Write //enumcheck:composite
+//	)
+func verifyFlagDeclaration(reportf reportFn, pass *analysis.Pass, enum *enum) {
+	composite := map[types.Object]bool{}
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.ValueSpec)
+				if !hasCompositeComment(spec.Doc) && !hasCompositeComment(spec.Comment) {
+					continue
+				}
+				for _, name := range spec.Names {
+					composite[pass.TypesInfo.Defs[name]] = true
+				}
+			}
+		}
+	}
+
+	values := append([]types.Object{}, enum.Values...)
+	sort.SliceStable(values, func(i, k int) bool {
+		return values[i].Pos() < values[k].Pos()
+	})
+
+	var single uint64
+	bits := map[uint64]types.Object{}
+	for _, obj := range values {
+		value, ok := flagValue(obj)
+		if !ok {
+			reportf(obj.Pos(), "flag %v must be a constant", obj.Name())
+			continue
+		}
+		if composite[obj] {
+			continue
+		}
+		if value == 0 || value&(value-1) != 0 {
+			reportf(obj.Pos(), "flag %v is not a single bit", obj.Name())
+			continue
+		}
+		if other, exists := bits[value]; exists {
+			reportf(obj.Pos(), "flag %v duplicates %v", obj.Name(), other.Name())
+			continue
+		}
+		bits[value] = obj
+		single |= value
+	}
+
+	for _, obj := range values {
+		value, ok := flagValue(obj)
+		if !ok || !composite[obj] {
+			continue
+		}
+		if value&^single != 0 {
+			reportf(obj.Pos(), "composite %v has bits outside of %v", obj.Name(), enum.Type)
+		}
+	}
+}
+
+func hasCompositeComment(group *ast.CommentGroup) bool {
+	if group == nil {
+		return false
+	}
+	for _, c := range group.List {
+		if c, ok := isEnumcheckComment(c.Text); ok && c.composite {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// collectGroups finds values annotated with `//enumcheck:group=name`.
+//
+//	const (
+//		Monday Day = iota
+//		...
+//		Saturday //enumcheck:group=weekend
+//		Sunday   //enumcheck:group=weekend
+//	)
+//
+//	var _ Expr = Add{} //enumcheck:group=binary
+func collectGroups(pass *analysis.Pass, pkgEnums enumSet) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || (decl.Tok != token.CONST && decl.Tok != token.VAR) {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.ValueSpec)
+
+				groups := groupComments(spec.Doc)
+				groups = append(groups, groupComments(spec.Comment)...)
+				if len(decl.Specs) == 1 {
+					groups = append(groups, groupComments(decl.Doc)...)
+				}
+				if len(groups) == 0 {
+					continue
+				}
+
+				// var _ Expr = Add{}
+				if spec.Type != nil {
+					if enum, ok := pkgEnums.Of(pass.TypesInfo.TypeOf(spec.Type)); ok && enum.TypeEnum {
+						for _, value := range spec.Values {
+							typ := pass.TypesInfo.TypeOf(value)
+							if !enum.ContainsType(typ) {
+								continue
+							}
+							for _, group := range groups {
+								if enum.TypeGroups == nil {
+									enum.TypeGroups = map[string][]types.Type{}
+								}
+								if !containsType(enum.TypeGroups[group], typ) {
+									enum.TypeGroups[group] = append(enum.TypeGroups[group], typ)
+								}
+							}
+						}
+						continue
+					}
+				}
+
+				for _, name := range spec.Names {
+					obj := pass.TypesInfo.Defs[name]
+					if obj == nil {
+						continue
+					}
+					enum, ok := pkgEnums.Of(obj.Type())
+					if !ok || !containsObject(enum.Values, obj) {
+						continue
+					}
+					for _, group := range groups {
+						if enum.Groups == nil {
+							enum.Groups = map[string][]types.Object{}
+						}
+						enum.Groups[group] = append(enum.Groups[group], obj)
+					}
+				}
+			}
+		}
+	}
+}
+
+// groupComments returns the group names listed in comments.
+func groupComments(group *ast.CommentGroup) []string {
+	if group == nil {
+		return nil
+	}
+	var groups []string
+	for _, c := range group.List {
+		if x, ok := isEnumcheckComment(c.Text); ok {
+			groups = append(groups, x.groups...)
+		}
+	}
+	return groups
+}
+
+func containsType(typs []types.Type, typ types.Type) bool {
+	for _, x := range typs {
+		if types.Identical(x, typ) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package enumcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// verifyEnumLiteral reports enum values that are constructed outside of
+// the enum declaration.
+//
+//	var True = Option{"true"} // ok
+//	x = Option{"maybe?"}      // error
+func verifyEnumLiteral(reportf reportFn, pass *analysis.Pass, enums enumSet, n *ast.CompositeLit, stack []ast.Node) {
+	enum, ok := enums.Of(pass.TypesInfo.TypeOf(n))
+	if !ok || enum.TypeEnum {
+		return
+	}
+
+	for i := len(stack) - 2; i >= 0; i-- {
+		if spec, ok := stack[i].(*ast.ValueSpec); ok && enum.IsDeclaration(spec) {
+			return
+		}
+	}
+
+	reportf(n.Pos(), "invalid enum for %v", enum.Type)
+}
+
+// verifyCompositeLit checks that map and array literals keyed by an enum
+// contain all the values.
+//
+//	var names = map[Letter]string{Alpha: "alpha", Beta: "beta"}
+//	var names = [...]string{Alpha: "alpha", Beta: "beta"}
+//	var names = [numLetters]string{"alpha", "beta"}
+func verifyCompositeLit(reportf reportFn, checkOverride overrideFn, pass *analysis.Pass, enums enumSet, n *ast.CompositeLit) {
+	if len(n.Elts) == 0 {
+		return
+	}
+
+	typ := pass.TypesInfo.TypeOf(n)
+	if typ == nil {
+		return
+	}
+
+	var enum *enum
+	var missing []string
+	switch t := typ.Underlying().(type) {
+	case *types.Map:
+		var ok bool
+		enum, ok = enums.Of(t.Key())
+		if !ok || enum.TypeEnum {
+			return
+		}
+		missing = missingMapKeys(reportf, pass, enum, n)
+	case *types.Array, *types.Slice:
+		enum, missing = missingArrayKeys(pass, enums, n)
+		if enum == nil {
+			return
+		}
+	default:
+		return
+	}
+
+	mode := enum.Mode
+	if override, ok := checkOverride(n.Pos()); ok {
+		mode = override.mode
+	}
+	if mode.ShouldIgnore() {
+		return
+	}
+
+	if len(missing) > 0 {
+		reportf(n.Pos(), "missing keys %v", humaneList(missing))
+	}
+}
+
+// missingMapKeys returns enum values that are not used as keys in a map literal.
+func missingMapKeys(reportf reportFn, pass *analysis.Pass, enum *enum, n *ast.CompositeLit) []string {
+	foundValues := map[types.Object]struct{}{}
+	for _, elt := range n.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		switch key := ast.Unparen(kv.Key).(type) {
+		case *ast.BasicLit:
+			reportf(key.Pos(), "implicit conversion of %v to %v", key.Value, enum.Type)
+		case *ast.Ident:
+			foundValues[memberOf(pass, pass.TypesInfo.ObjectOf(key))] = struct{}{}
+		case *ast.SelectorExpr:
+			foundValues[memberOf(pass, pass.TypesInfo.ObjectOf(key.Sel))] = struct{}{}
+		}
+	}
+
+	missing := []string{}
+	for _, obj := range enum.Required() {
+		if !isAccessible(pass, obj) {
+			// unexported values cannot be used as keys outside of their package
+			continue
+		}
+		if _, exists := foundValues[obj]; !exists {
+			missing = append(missing, obj.Name())
+		}
+	}
+	return missing
+}
+
+// missingArrayKeys returns enum values that are not used as indices in an
+// array or slice literal. The enum is determined either from the array length
+// or from the keys of the literal.
+func missingArrayKeys(pass *analysis.Pass, enums enumSet, n *ast.CompositeLit) (*enum, []string) {
+	var enum *enum
+
+	// [numLetters]string{...}
+	length := int64(-1)
+	if arrayType, ok := n.Type.(*ast.ArrayType); ok && arrayType.Len != nil {
+		if e, ok := enums.Of(pass.TypesInfo.TypeOf(arrayType.Len)); ok && !e.TypeEnum {
+			if tv, ok := pass.TypesInfo.Types[arrayType.Len]; ok && tv.Value != nil {
+				enum = e
+				length, _ = constant.Int64Val(constant.ToInt(tv.Value))
+			}
+		}
+	}
+
+	// [...]string{Alpha: "alpha", ...}
+	if enum == nil {
+		for _, elt := range n.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if e, ok := enums.Of(pass.TypesInfo.TypeOf(kv.Key)); ok && !e.TypeEnum {
+					enum = e
+					break
+				}
+			}
+		}
+	}
+	if enum == nil {
+		return nil, nil
+	}
+
+	covered := map[int64]bool{}
+	index := int64(0)
+	for _, elt := range n.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			tv, ok := pass.TypesInfo.Types[kv.Key]
+			if !ok || tv.Value == nil {
+				continue
+			}
+			index, _ = constant.Int64Val(constant.ToInt(tv.Value))
+		}
+		covered[index] = true
+		index++
+	}
+
+	missing := []string{}
+	named := map[int64]bool{}
+	for _, obj := range enum.Values {
+		value, ok := enumIndex(obj)
+		if !ok {
+			continue
+		}
+		if length >= 0 && value >= length {
+			continue
+		}
+		named[value] = true
+		if !covered[value] && !enum.IsDeprecated(obj) && isAccessible(pass, obj) {
+			missing = append(missing, obj.Name())
+		}
+	}
+
+	// indices without a corresponding enum value
+	for i := int64(0); i < length; i++ {
+		if !covered[i] && !named[i] {
+			missing = append(missing, fmt.Sprint(i))
+		}
+	}
+
+	return enum, missing
+}
+
+// enumIndex returns the integer value of a constant enum value.
+func enumIndex(obj types.Object) (int64, bool) {
+	c, ok := obj.(*types.Const)
+	if !ok {
+		return 0, false
+	}
+	value := constant.ToInt(c.Val())
+	if value.Kind() != constant.Int {
+		return 0, false
+	}
+	return constant.Int64Val(value)
+}
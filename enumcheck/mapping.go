@@ -0,0 +1,143 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// mapping is a function annotated with `//enumcheck:mapping` that converts
+// between two enums.
+//
+//	//enumcheck:mapping
+//	func toProto(s Status) pb.Status {
+//		switch s {
+//		case Active:
+//			return pb.Status_ACTIVE
+//		...
+type mapping struct {
+	decl   *ast.FuncDecl
+	param  types.Object
+	source *enum
+	target *enum
+	result int
+
+	bijective bool
+}
+
+// mappingFunc returns the mapping declared by decl.
+func mappingFunc(pass *analysis.Pass, enums enumSet, decl *ast.FuncDecl) (*mapping, bool) {
+	if decl == nil || decl.Doc == nil || decl.Body == nil {
+		return nil, false
+	}
+
+	m := &mapping{decl: decl, result: -1}
+
+	annotated := false
+	for _, c := range decl.Doc.List {
+		if c, ok := isEnumcheckComment(c.Text); ok && c.mapping {
+			annotated = true
+			m.bijective = c.bijective
+			break
+		}
+	}
+	if !annotated {
+		return nil, false
+	}
+
+	for _, field := range decl.Type.Params.List {
+		enum, ok := enums.Of(pass.TypesInfo.TypeOf(field.Type))
+		if !ok || enum.TypeEnum || len(field.Names) == 0 {
+			continue
+		}
+		m.source = enum
+		m.param = pass.TypesInfo.Defs[field.Names[0]]
+		break
+	}
+
+	if sig, ok := pass.TypesInfo.TypeOf(decl.Name).(*types.Signature); ok {
+		results := sig.Results()
+		for i := 0; i < results.Len(); i++ {
+			enum, ok := enums.Of(results.At(i).Type())
+			if !ok || enum.TypeEnum {
+				continue
+			}
+			m.target = enum
+			m.result = i
+			break
+		}
+	}
+
+	return m, true
+}
+
+// verifyMapping checks that a mapping switches over all source values and
+// returns only target values.
+func verifyMapping(reportf reportFn, pass *analysis.Pass, enums enumSet, decl *ast.FuncDecl) {
+	m, ok := mappingFunc(pass, enums, decl)
+	if !ok {
+		return
+	}
+	if m.source == nil || m.param == nil || m.target == nil {
+		reportf(decl.Pos(), "mapping %v must convert an enum parameter to an enum result", decl.Name.Name)
+		return
+	}
+
+	results := pass.TypesInfo.TypeOf(decl.Name).(*types.Signature).Results()
+
+	foundSwitch := false
+	produced := map[types.Object]int{}
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.SwitchStmt:
+			if tag, ok := ast.Unparen(n.Tag).(*ast.Ident); ok && pass.TypesInfo.ObjectOf(tag) == m.param {
+				foundSwitch = true
+			}
+		case *ast.ReturnStmt:
+			if len(n.Results) != results.Len() {
+				return true
+			}
+
+			ret := ast.Unparen(n.Results[m.result])
+			var obj types.Object
+			switch ret := ret.(type) {
+			case *ast.BasicLit:
+				// reported as an implicit conversion
+				return true
+			case *ast.Ident:
+				obj = memberOf(pass, pass.TypesInfo.ObjectOf(ret))
+			case *ast.SelectorExpr:
+				obj = memberOf(pass, pass.TypesInfo.ObjectOf(ret.Sel))
+			}
+			if !containsObject(m.target.Values, obj) {
+				reportf(ret.Pos(), "%v is not a value of %v", types.ExprString(ret), m.target.Type)
+				return true
+			}
+
+			produced[obj]++
+			if m.bijective && produced[obj] == 2 {
+				reportf(ret.Pos(), "%v is produced more than once", obj.Name())
+			}
+		}
+		return true
+	})
+
+	if !foundSwitch {
+		reportf(decl.Pos(), "mapping %v does not switch on %v", decl.Name.Name, m.param.Name())
+	}
+
+	if m.bijective {
+		missing := []string{}
+		for _, obj := range m.target.Required() {
+			if produced[obj] == 0 {
+				missing = append(missing, obj.Name())
+			}
+		}
+		if len(missing) > 0 {
+			reportf(decl.Pos(), "%v never produces %v", decl.Name.Name, humaneList(missing))
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// verifyMutation reports modifications of enum values declared with var,
+// outside of package initialization.
+//
+//	True = False        // error
+//	True.value = "yes"  // error
+//	p := &True          // error
+//	True.Set("yes")     // error, when Set has a pointer receiver
+//	Maybe.Set("maybe")  // error, also when Maybe is a pointer
+func verifyMutation(reportf reportFn, pass *analysis.Pass, enums enumSet, n ast.Node, stack []ast.Node) {
+	if decl := enclosingFunc(stack); decl == nil || (decl.Recv == nil && decl.Name.Name == "init") {
+		// package initialization
+		return
+	}
+
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		if n.Tok == token.DEFINE {
+			return
+		}
+		for _, lhs := range n.Lhs {
+			verifyMutationOf(reportf, pass, enums, lhs)
+		}
+	case *ast.IncDecStmt:
+		verifyMutationOf(reportf, pass, enums, n.X)
+	case *ast.UnaryExpr:
+		if n.Op != token.AND {
+			return
+		}
+		if obj, direct := enumValueOf(pass, enums, n.X); obj != nil {
+			if direct {
+				reportf(n.Pos(), "address of enum value %v", obj.Name())
+			} else {
+				reportf(n.Pos(), "mutation of enum value %v", obj.Name())
+			}
+		}
+	case *ast.SelectorExpr:
+		// calling a pointer method takes the address implicitly
+		sel, ok := pass.TypesInfo.Selections[n]
+		if !ok || sel.Kind() != types.MethodVal {
+			return
+		}
+		sig, ok := sel.Obj().Type().(*types.Signature)
+		if !ok || sig.Recv() == nil {
+			return
+		}
+		if _, pointerRecv := sig.Recv().Type().(*types.Pointer); !pointerRecv {
+			return
+		}
+		if obj, _ := enumValueOf(pass, enums, n.X); obj != nil {
+			reportf(n.Pos(), "mutation of enum value %v through pointer method %v", obj.Name(), n.Sel.Name)
+		}
+	}
+}
+
+func verifyMutationOf(reportf reportFn, pass *analysis.Pass, enums enumSet, expr ast.Expr) {
+	obj, direct := enumValueOf(pass, enums, expr)
+	if obj == nil {
+		return
+	}
+	if direct {
+		reportf(expr.Pos(), "assignment to enum value %v", obj.Name())
+	} else {
+		reportf(expr.Pos(), "mutation of enum value %v", obj.Name())
+	}
+}
+
+// enumValueOf returns the enum value variable that expr refers to, either
+// directly or through a field, an index or a pointer indirection.
+func enumValueOf(pass *analysis.Pass, enums enumSet, expr ast.Expr) (obj types.Object, direct bool) {
+	direct = true
+	for {
+		switch x := expr.(type) {
+		case *ast.ParenExpr:
+			expr = x.X
+			continue
+		case *ast.SelectorExpr:
+			if _, isField := pass.TypesInfo.Selections[x]; isField {
+				expr, direct = x.X, false
+				continue
+			}
+			obj = pass.TypesInfo.ObjectOf(x.Sel)
+		case *ast.IndexExpr:
+			expr, direct = x.X, false
+			continue
+		case *ast.StarExpr:
+			expr, direct = x.X, false
+			continue
+		case *ast.Ident:
+			obj = pass.TypesInfo.ObjectOf(x)
+		}
+		break
+	}
+
+	v, ok := obj.(*types.Var)
+	if !ok {
+		return nil, false
+	}
+	enum, ok := enums.Of(v.Type())
+	if !ok || !containsObject(enum.Values, v) {
+		return nil, false
+	}
+	return v, direct
+}
@@ -0,0 +1,179 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// verifyNonZero reports zero values of enums annotated with `//enumcheck:nonzero`.
+//
+//	var x Option          // error
+//	s := Struct{}         // error, when Struct contains an Option field
+//	p := new(Option)      // error
+func verifyNonZero(reportf reportFn, pass *analysis.Pass, enums enumSet, n ast.Node) {
+	switch n := n.(type) {
+	case *ast.ValueSpec:
+		if n.Type == nil || len(n.Values) > 0 {
+			return
+		}
+		typ := pass.TypesInfo.TypeOf(n.Type)
+		if enum, ok := enums.Of(typ); ok && enum.IsDeclaration(n) {
+			return
+		}
+		if enum := zeroEnum(enums, typ); enum != nil {
+			reportf(n.Pos(), "zero value of %v is not a valid %v", typ, enum.Type)
+		}
+
+	case *ast.CompositeLit:
+		typ := pass.TypesInfo.TypeOf(n)
+		if typ == nil {
+			return
+		}
+		if _, ok := enums.Of(typ); ok {
+			return
+		}
+		st, ok := typ.Underlying().(*types.Struct)
+		if !ok {
+			return
+		}
+		if len(n.Elts) > 0 {
+			if _, keyed := n.Elts[0].(*ast.KeyValueExpr); !keyed {
+				// the compiler requires all fields to be set
+				return
+			}
+		}
+
+		set := map[string]bool{}
+		for _, elt := range n.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if key, ok := kv.Key.(*ast.Ident); ok {
+					set[key.Name] = true
+				}
+			}
+		}
+
+		for i := 0; i < st.NumFields(); i++ {
+			field := st.Field(i)
+			if set[field.Name()] {
+				continue
+			}
+			if enum := zeroEnum(enums, field.Type()); enum != nil {
+				reportf(n.Pos(), "missing field %v, zero value is not a valid %v", field.Name(), enum.Type)
+			}
+		}
+
+	case *ast.CallExpr:
+		fn, ok := ast.Unparen(n.Fun).(*ast.Ident)
+		if !ok || len(n.Args) != 1 {
+			return
+		}
+		if builtin, ok := pass.TypesInfo.Uses[fn].(*types.Builtin); !ok || builtin.Name() != "new" {
+			return
+		}
+		typ := pass.TypesInfo.TypeOf(n.Args[0])
+		if enum := zeroEnum(enums, typ); enum != nil {
+			reportf(n.Pos(), "zero value of %v is not a valid %v", typ, enum.Type)
+		}
+	}
+}
+
+// zeroEnum returns the nonzero enum that is invalid in the zero value of typ.
+func zeroEnum(enums enumSet, typ types.Type) *enum {
+	return zeroEnumVisit(enums, typ, map[types.Type]bool{})
+}
+
+func zeroEnumVisit(enums enumSet, typ types.Type, visited map[types.Type]bool) *enum {
+	if typ == nil || visited[typ] {
+		return nil
+	}
+	visited[typ] = true
+
+	if enum, ok := enums.Of(typ); ok {
+		if enum.NonZero {
+			return enum
+		}
+		return nil
+	}
+
+	switch t := typ.Underlying().(type) {
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if enum := zeroEnumVisit(enums, t.Field(i).Type(), visited); enum != nil {
+				return enum
+			}
+		}
+	case *types.Array:
+		if t.Len() > 0 {
+			return zeroEnumVisit(enums, t.Elem(), visited)
+		}
+	}
+	return nil
+}
+
+// verifyNonZeroDeclaration reports values that are equal to the zero value.
+func verifyNonZeroDeclaration(reportf reportFn, pass *analysis.Pass, enum *enum) {
+	for _, obj := range enum.Values {
+		if c, ok := obj.(*types.Const); ok && isZeroConstant(c.Val()) {
+			reportf(obj.Pos(), "value %v equals the zero value of %v", obj.Name(), enum.Type)
+		}
+	}
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.ValueSpec)
+				for i, name := range spec.Names {
+					if i >= len(spec.Values) || !containsObject(enum.Values, pass.TypesInfo.Defs[name]) {
+						continue
+					}
+					if isZeroExpr(pass, spec.Values[i]) {
+						reportf(name.Pos(), "value %v equals the zero value of %v", name.Name, enum.Type)
+					}
+				}
+			}
+		}
+	}
+}
+
+// isZeroExpr returns whether expr is a zero constant or a composite literal
+// of zero constants.
+func isZeroExpr(pass *analysis.Pass, expr ast.Expr) bool {
+	expr = ast.Unparen(expr)
+	if tv, ok := pass.TypesInfo.Types[expr]; ok && tv.Value != nil {
+		return isZeroConstant(tv.Value)
+	}
+
+	switch expr := expr.(type) {
+	case *ast.CompositeLit:
+		for _, elt := range expr.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			if !isZeroExpr(pass, elt) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func isZeroConstant(value constant.Value) bool {
+	switch value.Kind() {
+	case constant.Bool:
+		return !constant.BoolVal(value)
+	case constant.String:
+		return constant.StringVal(value) == ""
+	case constant.Int, constant.Float, constant.Complex:
+		return constant.Sign(value) == 0
+	}
+	return false
+}
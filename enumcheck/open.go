@@ -0,0 +1,150 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// packageMembersFact lists values and types that a package contributes to
+// open enums declared in other packages.
+type packageMembersFact struct {
+	members map[types.Type]*openMembers
+}
+
+// openMembers are the values and types contributed to an open enum.
+type openMembers struct {
+	Values     []types.Object
+	Types      []types.Type
+	ValueSpecs []*ast.ValueSpec
+}
+
+func (*packageMembersFact) AFact() {}
+func (pkg *packageMembersFact) String() string {
+	texts := []string{}
+	for typ, members := range pkg.members {
+		names := []string{}
+		for _, obj := range members.Values {
+			names = append(names, obj.Name())
+		}
+		for _, typ := range members.Types {
+			names = append(names, typ.String())
+		}
+		texts = append(texts, typ.String()+" += {"+strings.Join(names, " | ")+"}")
+	}
+	sort.Strings(texts)
+	return strings.Join(texts, ", ")
+}
+
+// collectOpenMembers finds values and types of open enums that are declared
+// outside of the enum package.
+//
+//	package gzip
+//
+//	var _ codec.Codec = Codec{}
+//	const Gzip codec.Kind = 10
+func collectOpenMembers(pass *analysis.Pass, enums enumSet) map[types.Type]*openMembers {
+	members := map[types.Type]*openMembers{}
+	membersOf := func(typ types.Type) *openMembers {
+		enum, ok := enums.Of(typ)
+		if !ok || !enum.Open || enum.Pkg == pass.Pkg {
+			return nil
+		}
+		m, ok := members[enum.Type]
+		if !ok {
+			m = &openMembers{}
+			members[enum.Type] = m
+		}
+		return m
+	}
+
+	scope := pass.Pkg.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		switch obj.(type) {
+		case *types.Const, *types.Var:
+			if m := membersOf(obj.Type()); m != nil {
+				m.Values = append(m.Values, obj)
+			}
+		}
+	}
+
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || (decl.Tok != token.CONST && decl.Tok != token.VAR) {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.ValueSpec)
+				if spec.Type == nil {
+					continue
+				}
+				m := membersOf(pass.TypesInfo.TypeOf(spec.Type))
+				if m == nil {
+					continue
+				}
+				m.ValueSpecs = append(m.ValueSpecs, spec)
+				if !types.IsInterface(pass.TypesInfo.TypeOf(spec.Type)) {
+					continue
+				}
+				for _, value := range spec.Values {
+					m.Types = append(m.Types, pass.TypesInfo.TypeOf(value))
+				}
+			}
+		}
+	}
+
+	for typ, m := range members {
+		if len(m.Values) == 0 && len(m.Types) == 0 {
+			delete(members, typ)
+		}
+	}
+	return members
+}
+
+// extendOpenEnums adds the values and types contributed by the packages
+// visible from the current package to the open enums.
+//
+// The enums are copied, because they are shared between packages.
+func extendOpenEnums(pass *analysis.Pass, enums enumSet) {
+	for _, fact := range pass.AllPackageFacts() {
+		pkgMembers, ok := fact.Fact.(*packageMembersFact)
+		if !ok {
+			continue
+		}
+		for typ, members := range pkgMembers.members {
+			original, ok := enums[typ]
+			if !ok {
+				continue
+			}
+
+			extended := *original
+			extended.Values = append(slices.Clip(extended.Values), members.Values...)
+			extended.Types = append(slices.Clip(extended.Types), members.Types...)
+			if fact.Package == pass.Pkg {
+				extended.ValueSpecs = append(slices.Clip(extended.ValueSpecs), members.ValueSpecs...)
+			}
+			sort.Slice(extended.Values, func(i, k int) bool {
+				return extended.Values[i].Name() < extended.Values[k].Name()
+			})
+			sort.Slice(extended.Types, func(i, k int) bool {
+				return extended.Types[i].String() < extended.Types[k].String()
+			})
+			enums[typ] = &extended
+		}
+	}
+}
+
+// seesAllMembers returns whether all the members of enum are visible from
+// the current package. Analysis only sees the facts of the imported packages,
+// so only a main package, which imports every package linked into the
+// program, is guaranteed to see every contributor.
+func seesAllMembers(pass *analysis.Pass, enum *enum) bool {
+	return !enum.Open || (enum.Pkg != pass.Pkg && pass.Pkg.Name() == "main")
+}
@@ -0,0 +1,109 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// isStringer returns whether decl is a String or MarshalText method of enum.
+func isStringer(pass *analysis.Pass, decl *ast.FuncDecl, enum *enum) bool {
+	if decl == nil || decl.Recv == nil || len(decl.Recv.List) != 1 {
+		return false
+	}
+	if decl.Name.Name != "String" && decl.Name.Name != "MarshalText" {
+		return false
+	}
+
+	recv := pass.TypesInfo.TypeOf(decl.Recv.List[0].Type)
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	return recv == enum.Type
+}
+
+// verifyProduces checks that a function annotated with
+// `//enumcheck:produces Letter` returns every value of the enum.
+//
+//	//enumcheck:produces Letter
+//	func ParseLetter(s string) (Letter, error) {
+//		switch s {
+//		case "alpha":
+//			return Alpha, nil
+//		...
+func verifyProduces(reportf reportFn, pass *analysis.Pass, enums enumSet, decl *ast.FuncDecl) {
+	if decl.Doc == nil || decl.Body == nil {
+		return
+	}
+
+	name := ""
+	for _, c := range decl.Doc.List {
+		if c, ok := isEnumcheckComment(c.Text); ok && c.produces != "" {
+			name = c.produces
+			break
+		}
+	}
+	if name == "" {
+		return
+	}
+
+	sig, ok := pass.TypesInfo.TypeOf(decl.Name).(*types.Signature)
+	if !ok {
+		return
+	}
+
+	var enum *enum
+	index := -1
+	results := sig.Results()
+	for i := 0; i < results.Len(); i++ {
+		typ := results.At(i).Type()
+		e, ok := enums.Of(typ)
+		if !ok || e.TypeEnum {
+			continue
+		}
+		if types.TypeString(typ, types.RelativeTo(pass.Pkg)) == name {
+			enum, index = e, i
+			break
+		}
+		if named, ok := typ.(*types.Named); ok && named.Obj().Name() == name {
+			enum, index = e, i
+			break
+		}
+	}
+	if enum == nil {
+		reportf(decl.Pos(), "%v does not return enum %v", decl.Name.Name, name)
+		return
+	}
+
+	foundValues := map[types.Object]struct{}{}
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) != results.Len() {
+				// bare returns and returning tuples are not tracked
+				return true
+			}
+			switch ret := ast.Unparen(n.Results[index]).(type) {
+			case *ast.Ident:
+				foundValues[memberOf(pass, pass.TypesInfo.ObjectOf(ret))] = struct{}{}
+			case *ast.SelectorExpr:
+				foundValues[memberOf(pass, pass.TypesInfo.ObjectOf(ret.Sel))] = struct{}{}
+			}
+		}
+		return true
+	})
+
+	missing := []string{}
+	for _, obj := range enum.Required() {
+		if _, exists := foundValues[obj]; !exists {
+			missing = append(missing, obj.Name())
+		}
+	}
+
+	if len(missing) > 0 {
+		reportf(decl.Pos(), "%v never produces %v", decl.Name.Name, humaneList(missing))
+	}
+}
@@ -0,0 +1,269 @@
+package enumcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// verifyProduct checks that a switch over an array or a struct of enums
+// handles every combination of the values.
+//
+//	switch [2]Phase{from, to} { //enumcheck:product
+//	case [2]Phase{Idle, Running}:
+//	case [2]Phase{Running, Done}:
+//	}
+func verifyProduct(reportf reportFn, pass *analysis.Pass, enums enumSet, n *ast.SwitchStmt, mode enumMode) {
+	typ := pass.TypesInfo.TypeOf(n.Tag)
+	dims, fields, ok := productDims(enums, typ)
+	if !ok {
+		reportf(n.Pos(), "enumcheck:product requires an array or a struct of enums, got %v", typ)
+		return
+	}
+
+	values := make([][]types.Object, len(dims))
+	patterns := 1
+	for i, enum := range dims {
+		values[i] = enum.Required()
+		sort.SliceStable(values[i], func(a, b int) bool {
+			return values[i][a].Pos() < values[i][b].Pos()
+		})
+		if patterns <= maxProductPatterns {
+			patterns *= len(values[i]) + 1
+		}
+	}
+	if patterns > maxProductPatterns {
+		reportf(n.Pos(), "enumcheck:product has too many combinations to verify")
+		return
+	}
+
+	found := map[string]bool{}
+	foundDefault := false
+	verifiable := true
+	for _, clause := range n.Body.List {
+		clause := clause.(*ast.CaseClause)
+		if clause.List == nil {
+			foundDefault = true
+			continue
+		}
+		for _, option := range clause.List {
+			var tuple []int
+			lit, ok := ast.Unparen(option).(*ast.CompositeLit)
+			if ok {
+				tuple, ok = productTuple(pass, lit, fields, values)
+			}
+			if !ok {
+				reportf(option.Pos(), "enumcheck:product cannot verify case, it must be a literal of enum values")
+				verifiable = false
+				continue
+			}
+			found[tupleKey(tuple)] = true
+		}
+	}
+	if !verifiable {
+		return
+	}
+
+	var missing []string
+	if mode != modeComplete || !foundDefault {
+		missing = missingProducts(values, found)
+	}
+	if mode.NeedsDefault() && !foundDefault {
+		missing = append(missing, "default")
+	}
+	if mode.ShouldIgnore() {
+		missing = nil
+	}
+
+	if len(missing) > 0 {
+		reportf(n.Pos(), "missing cases %v", humaneList(missing))
+	}
+}
+
+// maxProductPatterns limits the number of patterns considered by
+// missingProducts, which grows as the product of the enum sizes.
+const maxProductPatterns = 1 << 12
+
+// productDims returns the enums of each element of an array or each field
+// of a struct.
+func productDims(enums enumSet, typ types.Type) ([]*enum, []string, bool) {
+	switch t := typ.Underlying().(type) {
+	case *types.Array:
+		e, ok := enums.Of(t.Elem())
+		if !ok || e.TypeEnum || t.Len() == 0 {
+			return nil, nil, false
+		}
+		dims := make([]*enum, t.Len())
+		for i := range dims {
+			dims[i] = e
+		}
+		return dims, nil, true
+	case *types.Struct:
+		if t.NumFields() == 0 {
+			return nil, nil, false
+		}
+		var dims []*enum
+		var fields []string
+		for i := 0; i < t.NumFields(); i++ {
+			e, ok := enums.Of(t.Field(i).Type())
+			if !ok || e.TypeEnum {
+				return nil, nil, false
+			}
+			dims = append(dims, e)
+			fields = append(fields, t.Field(i).Name())
+		}
+		return dims, fields, true
+	}
+	return nil, nil, false
+}
+
+// productTuple returns the indices of the values in lit.
+func productTuple(pass *analysis.Pass, lit *ast.CompositeLit, fields []string, values [][]types.Object) ([]int, bool) {
+	tuple := make([]int, len(values))
+	for i := range tuple {
+		tuple[i] = -1
+	}
+
+	for i, elt := range lit.Elts {
+		dim := i
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			elt = kv.Value
+			if key, ok := kv.Key.(*ast.Ident); ok && fields != nil {
+				dim = indexOf(fields, key.Name)
+			} else if tv, ok := pass.TypesInfo.Types[kv.Key]; ok && tv.Value != nil {
+				index, _ := constant.Int64Val(constant.ToInt(tv.Value))
+				dim = int(index)
+			}
+		}
+		if dim < 0 || dim >= len(tuple) {
+			return nil, false
+		}
+
+		var obj types.Object
+		switch elt := ast.Unparen(elt).(type) {
+		case *ast.Ident:
+			obj = memberOf(pass, pass.TypesInfo.ObjectOf(elt))
+		case *ast.SelectorExpr:
+			obj = memberOf(pass, pass.TypesInfo.ObjectOf(elt.Sel))
+		}
+		tuple[dim] = indexOfObject(values[dim], obj)
+	}
+
+	for _, index := range tuple {
+		if index < 0 {
+			return nil, false
+		}
+	}
+	return tuple, true
+}
+
+// missingProducts returns the combinations that are not found. Combinations
+// are merged into patterns with wildcards, e.g. "(Beta, *)".
+func missingProducts(values [][]types.Object, found map[string]bool) []string {
+	// patterns use -1 for a wildcard
+	var patterns [][]int
+	var walk func(pattern []int)
+	walk = func(pattern []int) {
+		if len(pattern) == len(values) {
+			patterns = append(patterns, append([]int(nil), pattern...))
+			return
+		}
+		for index := -1; index < len(values[len(pattern)]); index++ {
+			walk(append(pattern, index))
+		}
+	}
+	walk(nil)
+
+	wildcards := func(pattern []int) int {
+		count := 0
+		for _, index := range pattern {
+			if index < 0 {
+				count++
+			}
+		}
+		return count
+	}
+	sort.SliceStable(patterns, func(i, k int) bool {
+		return wildcards(patterns[i]) > wildcards(patterns[k])
+	})
+
+	reported := map[string]bool{}
+	var missing []string
+	for _, pattern := range patterns {
+		tuples := expandPattern(values, pattern)
+		allMissing, anyNew := true, false
+		for _, tuple := range tuples {
+			key := tupleKey(tuple)
+			if found[key] {
+				allMissing = false
+				break
+			}
+			if !reported[key] {
+				anyNew = true
+			}
+		}
+		if !allMissing || !anyNew {
+			continue
+		}
+		for _, tuple := range tuples {
+			reported[tupleKey(tuple)] = true
+		}
+
+		names := make([]string, len(pattern))
+		for dim, index := range pattern {
+			if index < 0 {
+				names[dim] = "*"
+			} else {
+				names[dim] = values[dim][index].Name()
+			}
+		}
+		missing = append(missing, "("+strings.Join(names, ", ")+")")
+	}
+	return missing
+}
+
+// expandPattern returns all the combinations that match pattern.
+func expandPattern(values [][]types.Object, pattern []int) [][]int {
+	tuples := [][]int{nil}
+	for dim, index := range pattern {
+		var next [][]int
+		for _, tuple := range tuples {
+			if index >= 0 {
+				next = append(next, append(append([]int(nil), tuple...), index))
+				continue
+			}
+			for i := range values[dim] {
+				next = append(next, append(append([]int(nil), tuple...), i))
+			}
+		}
+		tuples = next
+	}
+	return tuples
+}
+
+func tupleKey(tuple []int) string {
+	return fmt.Sprint(tuple)
+}
+
+func indexOf(names []string, name string) int {
+	for i, x := range names {
+		if x == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func indexOfObject(objs []types.Object, obj types.Object) int {
+	for i, x := range objs {
+		if x == obj {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,138 @@
+package enumcheck
+
+import (
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// protobufEnums enables detection of enums and oneofs generated by protoc-gen-go.
+var protobufEnums bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&protobufEnums, "protobuf", false, "check enums and oneofs generated by protoc-gen-go")
+}
+
+// collectProtobuf adds enums and oneofs generated by protoc-gen-go,
+// since generated code cannot be annotated.
+//
+// Enums are relaxed and don't require handling the `*_UNSPECIFIED` value:
+//
+//	type Kind int32
+//
+//	const (
+//		Kind_KIND_UNSPECIFIED Kind = 0
+//		Kind_KIND_ADD         Kind = 1
+//	)
+//
+//	var Kind_name = map[int32]string{...}
+//	var Kind_value = map[string]int32{...}
+//
+//	func (x Kind) Enum() *Kind
+//	func (Kind) Descriptor() protoreflect.EnumDescriptor
+//
+// Oneofs are type enums of the wrapper structs:
+//
+//	type isMsg_Payload interface{ isMsg_Payload() }
+//
+//	func (*Msg_Text) isMsg_Payload() {}
+func collectProtobuf(pass *analysis.Pass, pkgEnums enumSet) {
+	scope := pass.Pkg.Scope()
+
+	detected := enumSet{}
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || obj.IsAlias() {
+			continue
+		}
+		typ := obj.Type()
+		if _, exists := pkgEnums[typ]; exists {
+			continue
+		}
+
+		switch {
+		case isProtobufEnum(scope, obj):
+			detected[typ] = &enum{
+				Pkg:  obj.Pkg(),
+				Type: typ,
+				Mode: modeRelaxed,
+			}
+		case isProtobufOneof(obj):
+			detected[typ] = &enum{
+				Pkg:      obj.Pkg(),
+				Type:     typ,
+				TypeEnum: true,
+				Mode:     modeRelaxed,
+			}
+		}
+	}
+
+	for _, name := range scope.Names() {
+		switch obj := scope.Lookup(name).(type) {
+		case *types.Const:
+			enum, ok := detected[obj.Type()]
+			if !ok || isUnspecified(obj) {
+				continue
+			}
+			enum.Values = append(enum.Values, obj)
+		case *types.TypeName:
+			if _, isStruct := obj.Type().Underlying().(*types.Struct); !isStruct {
+				continue
+			}
+			wrapper := types.NewPointer(obj.Type())
+			for _, enum := range detected {
+				if enum.TypeEnum && types.Implements(wrapper, enum.Type.Underlying().(*types.Interface)) {
+					enum.Types = append(enum.Types, wrapper)
+				}
+			}
+		}
+	}
+
+	for typ, enum := range detected {
+		pkgEnums[typ] = enum
+	}
+}
+
+// isProtobufEnum returns whether obj looks like an enum generated by
+// protoc-gen-go.
+func isProtobufEnum(scope *types.Scope, obj *types.TypeName) bool {
+	basic, ok := obj.Type().Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsInteger == 0 {
+		return false
+	}
+
+	for _, suffix := range []string{"_name", "_value"} {
+		v, ok := scope.Lookup(obj.Name() + suffix).(*types.Var)
+		if !ok {
+			return false
+		}
+		if _, isMap := v.Type().Underlying().(*types.Map); !isMap {
+			return false
+		}
+	}
+
+	methods := types.NewMethodSet(types.NewPointer(obj.Type()))
+	return methods.Lookup(obj.Pkg(), "Enum") != nil && methods.Lookup(obj.Pkg(), "Descriptor") != nil
+}
+
+// isProtobufOneof returns whether obj looks like a oneof interface
+// generated by protoc-gen-go, e.g. `isMsg_Payload`.
+func isProtobufOneof(obj *types.TypeName) bool {
+	if !strings.HasPrefix(obj.Name(), "is") || !strings.Contains(obj.Name(), "_") {
+		return false
+	}
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok || iface.NumMethods() != 1 {
+		return false
+	}
+	method := iface.Method(0)
+	sig := method.Type().(*types.Signature)
+	return method.Name() == obj.Name() && sig.Params().Len() == 0 && sig.Results().Len() == 0
+}
+
+// isUnspecified returns whether obj is the zero `*_UNSPECIFIED` value.
+func isUnspecified(obj *types.Const) bool {
+	return strings.HasSuffix(obj.Name(), "_UNSPECIFIED") && constant.Sign(obj.Val()) == 0
+}
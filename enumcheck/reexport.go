@@ -0,0 +1,82 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// reexportFact marks a constant or a variable that re-exports an enum value
+// declared in another package.
+type reexportFact struct {
+	original types.Object
+}
+
+func (*reexportFact) AFact() {}
+func (fact *reexportFact) String() string {
+	return fact.original.Pkg().Name() + "." + fact.original.Name()
+}
+
+// collectReexports exports facts for constants and variables that re-export
+// enum values from other packages.
+//
+//	package facade
+//
+//	type Letter = enumbyte.Letter
+//	const Alpha = enumbyte.Alpha
+func collectReexports(pass *analysis.Pass, enums enumSet) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || (decl.Tok != token.CONST && decl.Tok != token.VAR) {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.ValueSpec)
+				if len(spec.Names) != len(spec.Values) {
+					continue
+				}
+				for i, name := range spec.Names {
+					obj := pass.TypesInfo.Defs[name]
+					if obj == nil || name.Name == "_" {
+						continue
+					}
+
+					var ident *ast.Ident
+					switch value := ast.Unparen(spec.Values[i]).(type) {
+					case *ast.Ident:
+						ident = value
+					case *ast.SelectorExpr:
+						ident = value.Sel
+					default:
+						continue
+					}
+
+					original := memberOf(pass, pass.TypesInfo.Uses[ident])
+					if original == nil || original.Pkg() == pass.Pkg {
+						continue
+					}
+					enum, ok := enums.Of(original.Type())
+					if !ok || !containsObject(enum.Values, original) {
+						continue
+					}
+					pass.ExportObjectFact(obj, &reexportFact{original})
+				}
+			}
+		}
+	}
+}
+
+// memberOf returns the enum value that obj re-exports or obj itself.
+func memberOf(pass *analysis.Pass, obj types.Object) types.Object {
+	if obj == nil || obj.Pkg() == nil {
+		return obj
+	}
+	var fact reexportFact
+	if pass.ImportObjectFact(obj, &fact) {
+		return fact.original
+	}
+	return obj
+}
@@ -0,0 +1,139 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// collectSentinels moves values annotated with `//enumcheck:sentinel`
+// from enum values to sentinels.
+//
+//	const (
+//		Alpha Letter = iota
+//		Beta
+//		numLetters //enumcheck:sentinel
+//	)
+func collectSentinels(pass *analysis.Pass, pkgEnums enumSet) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || (decl.Tok != token.CONST && decl.Tok != token.VAR) {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.ValueSpec)
+				if !hasSentinelComment(spec.Doc) && !hasSentinelComment(spec.Comment) {
+					continue
+				}
+				for _, name := range spec.Names {
+					obj := pass.TypesInfo.Defs[name]
+					if obj == nil {
+						continue
+					}
+					enum, ok := pkgEnums.Of(obj.Type())
+					if !ok {
+						continue
+					}
+
+					for i, value := range enum.Values {
+						if value == obj {
+							enum.Values = append(enum.Values[:i:i], enum.Values[i+1:]...)
+							break
+						}
+					}
+					enum.Sentinels = append(enum.Sentinels, obj)
+				}
+			}
+		}
+	}
+}
+
+func hasSentinelComment(group *ast.CommentGroup) bool {
+	if group == nil {
+		return false
+	}
+	for _, c := range group.List {
+		if c, ok := isEnumcheckComment(c.Text); ok && c.sentinel {
+			return true
+		}
+	}
+	return false
+}
+
+// verifySentinelUse reports sentinels that are used as ordinary values:
+// assigned, returned, passed as an argument or used in a case clause.
+// Other uses, such as array sizes and loop bounds, are allowed.
+func verifySentinelUse(reportf reportFn, pass *analysis.Pass, enums enumSet, ident *ast.Ident, stack []ast.Node) {
+	obj := memberOf(pass, pass.TypesInfo.Uses[ident])
+	if obj == nil {
+		return
+	}
+	enum, ok := enums.Of(obj.Type())
+	if !ok || !enum.IsSentinel(obj) {
+		return
+	}
+
+	expr, parent, ok := valueUse(ident, stack)
+	if ok && isValueUse(pass, expr, parent, true) {
+		reportf(expr.Pos(), "sentinel %v used as a value", obj.Name())
+	}
+}
+
+// valueUse returns the expression that refers to ident and its parent.
+func valueUse(ident *ast.Ident, stack []ast.Node) (ast.Expr, ast.Node, bool) {
+	var expr ast.Expr = ident
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch parent := stack[i].(type) {
+		case *ast.SelectorExpr:
+			if parent.Sel != ident {
+				return nil, nil, false
+			}
+			expr = parent
+		case *ast.ParenExpr:
+			expr = parent
+		default:
+			return expr, parent, true
+		}
+	}
+	return nil, nil, false
+}
+
+// isValueUse returns whether expr is used as a value in parent:
+// assigned, returned, sent, passed as an argument, used as an element
+// or, when cases is set, used in a case clause.
+func isValueUse(pass *analysis.Pass, expr ast.Expr, parent ast.Node, cases bool) bool {
+	switch parent := parent.(type) {
+	case *ast.AssignStmt:
+		return containsExpr(parent.Rhs, expr)
+	case *ast.ValueSpec:
+		return containsExpr(parent.Values, expr)
+	case *ast.ReturnStmt:
+		return true
+	case *ast.CaseClause:
+		return cases && containsExpr(parent.List, expr)
+	case *ast.SendStmt:
+		return parent.Value == expr
+	case *ast.CompositeLit:
+		return containsExpr(parent.Elts, expr)
+	case *ast.KeyValueExpr:
+		return parent.Value == expr
+	case *ast.CallExpr:
+		if tv, ok := pass.TypesInfo.Types[parent.Fun]; ok && (tv.IsType() || tv.IsBuiltin()) {
+			// conversions and builtins, e.g. int(numLetters), make([]T, numLetters)
+			return false
+		}
+		return containsExpr(parent.Args, expr)
+	}
+	return false
+}
+
+func containsExpr(exprs []ast.Expr, expr ast.Expr) bool {
+	for _, x := range exprs {
+		if x == expr {
+			return true
+		}
+	}
+	return false
+}
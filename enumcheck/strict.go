@@ -0,0 +1,239 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/cfg"
+)
+
+// unreachableFact marks a function annotated with `//enumcheck:unreachable`.
+type unreachableFact struct{}
+
+func (*unreachableFact) AFact()         {}
+func (*unreachableFact) String() string { return "unreachable" }
+
+// collectUnreachable exports facts for functions annotated with
+// `//enumcheck:unreachable`.
+//
+//	//enumcheck:unreachable
+//	func unreachable(v any) { log.Printf("unreachable %v", v) }
+func collectUnreachable(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.FuncDecl)
+			if !ok || decl.Doc == nil {
+				continue
+			}
+			for _, c := range decl.Doc.List {
+				if c, ok := isEnumcheckComment(c.Text); ok && c.unreachable {
+					if obj := pass.TypesInfo.Defs[decl.Name]; obj != nil {
+						pass.ExportObjectFact(obj, &unreachableFact{})
+					}
+					break
+				}
+			}
+		}
+	}
+}
+
+// verifyStrictDefault checks that the default clause of a switch or the
+// final else of an if/else-if chain terminates abnormally: it panics,
+// returns a non-nil error, calls a function that doesn't return
+// (e.g. log.Fatal) or calls a function marked with `//enumcheck:unreachable`.
+func verifyStrictDefault(reportf reportFn, pass *analysis.Pass, n ast.Stmt, stack []ast.Node) {
+	var fallback ast.Node
+	switch n := n.(type) {
+	case *ast.SwitchStmt:
+		fallback = defaultClause(n.Body)
+	case *ast.TypeSwitchStmt:
+		fallback = defaultClause(n.Body)
+	case *ast.IfStmt:
+		for {
+			next, ok := n.Else.(*ast.IfStmt)
+			if !ok {
+				break
+			}
+			n = next
+		}
+		if n.Else != nil {
+			fallback = n.Else
+		}
+	}
+	if fallback == nil {
+		return
+	}
+
+	g, results := enclosingCFG(pass, stack)
+	if g == nil {
+		return
+	}
+
+	var body *cfg.Block
+	if typeSwitch, ok := n.(*ast.TypeSwitchStmt); ok {
+		body = typeSwitchDefault(g, typeSwitch)
+	}
+	for _, block := range g.Blocks {
+		switch block.Kind {
+		case cfg.KindSwitchCaseBody:
+			if block.Stmt == fallback {
+				body = block
+			}
+		case cfg.KindIfElse:
+			if ifStmt, ok := block.Stmt.(*ast.IfStmt); ok && ifStmt.Else == fallback {
+				body = block
+			}
+		}
+	}
+	if body == nil {
+		return
+	}
+	inside := func(block *cfg.Block) bool {
+		if block == body {
+			return true
+		}
+		return block.Stmt != nil && fallback.Pos() <= block.Stmt.Pos() && block.Stmt.End() <= fallback.End()
+	}
+
+	visited := map[*cfg.Block]bool{}
+	var failsLoudly func(block *cfg.Block) bool
+	failsLoudly = func(block *cfg.Block) bool {
+		if !inside(block) {
+			return false
+		}
+		if visited[block] {
+			// loops inside the default clause or the else branch
+			return true
+		}
+		visited[block] = true
+
+		for _, node := range block.Nodes {
+			if node.Pos() < fallback.Pos() || fallback.End() < node.End() {
+				// statements before a type switch share the block of its default
+				continue
+			}
+			switch node := node.(type) {
+			case *ast.ReturnStmt:
+				return returnsError(pass, results, node)
+			case *ast.ExprStmt:
+				if call, ok := ast.Unparen(node.X).(*ast.CallExpr); ok && isUnreachableCall(pass, call) {
+					return true
+				}
+			}
+		}
+
+		// the block ends with a call that doesn't return, e.g. panic or log.Fatal
+		if len(block.Succs) == 0 {
+			return true
+		}
+		for _, succ := range block.Succs {
+			if !failsLoudly(succ) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if !failsLoudly(body) {
+		reportf(fallback.Pos(), "default case must fail: panic, return an error or call an unreachable function")
+	}
+}
+
+// typeSwitchDefault returns the block that starts the default clause of a
+// type switch. The default clause doesn't get a block of its own, it continues
+// the block that checks the last type of the last case.
+func typeSwitchDefault(g *cfg.CFG, n *ast.TypeSwitchStmt) *cfg.Block {
+	var last *ast.CaseClause
+	for _, stmt := range n.Body.List {
+		if clause := stmt.(*ast.CaseClause); clause.List != nil {
+			last = clause
+		}
+	}
+
+	var body *cfg.Block
+	for _, block := range g.Blocks {
+		if last != nil {
+			if block.Kind == cfg.KindSwitchNextCase && block.Stmt == last {
+				body = block
+			}
+			continue
+		}
+		for _, node := range block.Nodes {
+			if node == n.Assign {
+				body = block
+			}
+		}
+	}
+	return body
+}
+
+// defaultClause returns the default clause of a switch body.
+func defaultClause(body *ast.BlockStmt) *ast.CaseClause {
+	for _, stmt := range body.List {
+		if clause := stmt.(*ast.CaseClause); clause.List == nil {
+			return clause
+		}
+	}
+	return nil
+}
+
+// enclosingCFG returns the control-flow graph and the results of the
+// innermost function in stack.
+func enclosingCFG(pass *analysis.Pass, stack []ast.Node) (*cfg.CFG, *ast.FieldList) {
+	cfgs := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncLit:
+			return cfgs.FuncLit(fn), fn.Type.Results
+		case *ast.FuncDecl:
+			return cfgs.FuncDecl(fn), fn.Type.Results
+		}
+	}
+	return nil, nil
+}
+
+// returnsError returns whether ret returns a non-nil error as the last result.
+func returnsError(pass *analysis.Pass, results *ast.FieldList, ret *ast.ReturnStmt) bool {
+	if results == nil || len(results.List) == 0 || len(ret.Results) == 0 {
+		return false
+	}
+	last := results.List[len(results.List)-1]
+	if !types.Identical(pass.TypesInfo.TypeOf(last.Type), types.Universe.Lookup("error").Type()) {
+		return false
+	}
+	result := ast.Unparen(ret.Results[len(ret.Results)-1])
+	if tv, ok := pass.TypesInfo.Types[result]; ok && tv.IsNil() {
+		return false
+	}
+	return true
+}
+
+// isUnreachableCall returns whether call calls a function marked with
+// `//enumcheck:unreachable`.
+func isUnreachableCall(pass *analysis.Pass, call *ast.CallExpr) bool {
+	var ident *ast.Ident
+	switch fn := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fn
+	case *ast.SelectorExpr:
+		ident = fn.Sel
+	default:
+		return false
+	}
+	obj, ok := pass.TypesInfo.Uses[ident].(*types.Func)
+	if !ok {
+		return false
+	}
+	return pass.ImportObjectFact(obj, new(unreachableFact))
+}
+
+// isStrict returns whether the switch at pos requires a strict default.
+func isStrict(checkOverride overrideFn, enum *enum, pos token.Pos) bool {
+	if override, ok := checkOverride(pos); ok && override.strict {
+		return true
+	}
+	return enum.Strict
+}
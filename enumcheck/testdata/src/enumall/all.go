@@ -0,0 +1,45 @@
+// want package:"enumall.Letter = {Alpha | Beta | Delta | Gamma}"
+package enumall
+
+// Letter is an enumerated type.
+//
+//enumcheck:exhaustive
+type Letter byte
+
+const (
+	Alpha Letter = iota
+	Beta
+	Gamma
+	Delta
+)
+
+//enumcheck:all
+var AllLetters = []Letter{Alpha, Beta, Gamma, Delta}
+
+//enumcheck:all
+var RepeatedLetters = []Letter{Alpha, Beta, Alpha, Gamma, Delta} // want "duplicate value Alpha"
+
+//enumcheck:all
+var MissingLetters = []Letter{Alpha, Gamma} // want "missing values Beta and Delta"
+
+var (
+	//enumcheck:all
+	DuplicateLetters = [...]Letter{ // want "missing values Delta"
+		Alpha,
+		Beta,
+		Beta, // want "duplicate value Beta"
+		Gamma,
+	}
+
+	InvalidLetters = []Letter{Alpha, 5} //enumcheck:all // want "implicit conversion of 5 to enumall.Letter" "missing values Beta, Delta and Gamma"
+)
+
+var NotChecked = []Letter{Alpha}
+
+//enumcheck:all
+func Letters() []Letter {
+	return []Letter{Delta, Gamma, Beta} // want "missing values Alpha"
+}
+
+//enumcheck:all
+var NotEnum = []int{1, 2, 3} // want "enumcheck:all element type int is not an enum"
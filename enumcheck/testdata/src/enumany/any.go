@@ -0,0 +1,93 @@
+package enumany
+
+import "enumtype"
+
+type Ping struct{}
+type Pong struct{}
+type Quit struct{}
+
+func Decode(msg any) string {
+	switch msg.(type) { //enumcheck:exhaustive=enumtype.Expr // want "missing cases enumtype.Div, enumtype.Value and default"
+	case enumtype.Add, enumtype.Mul:
+		return "expr"
+	case int: // want "int is not in the domain enumtype.Expr"
+		return "int"
+	}
+	return ""
+}
+
+func Handle(msg any) string {
+	switch msg := msg.(type) { //enumcheck:exhaustive=Ping|*Pong|Quit // want "missing cases enumany.Quit and default"
+	case Ping:
+		return "ping"
+	case *Pong:
+		return "pong"
+	case string: // want `string is not in the domain Ping\|\*Pong\|Quit`
+		return msg
+	}
+	return ""
+}
+
+func Exhaustive(msg any) string {
+	switch msg.(type) { //enumcheck:exhaustive=Ping|Pong|Quit
+	case Ping, Pong, Quit:
+		return "ok"
+	default:
+		return "unknown"
+	}
+}
+
+func ExhaustiveMissing(msg any) string {
+	switch msg.(type) { //enumcheck:exhaustive=Ping|Pong|Quit // want "missing cases default"
+	case Ping, Pong, Quit:
+		return "ok"
+	}
+	return ""
+}
+
+func Complete(msg any) string {
+	switch msg.(type) { //enumcheck:complete=Ping|Pong
+	case Ping:
+		return "ping"
+	default:
+		return "other"
+	}
+}
+
+func CompleteMissing(msg any) string {
+	switch msg.(type) { //enumcheck:complete=Ping|Pong // want "missing cases enumany.Pong"
+	case Ping:
+		return "ping"
+	}
+	return ""
+}
+
+func Relaxed(msg any) string {
+	switch msg.(type) { //enumcheck:relaxed=Ping|Pong // want "missing cases enumany.Pong"
+	case Ping:
+		return "ping"
+	default:
+		return "other"
+	}
+}
+
+func Closed(msg any) string {
+	switch msg.(type) { //enumcheck:closed=Ping|Pong
+	case Ping, Pong:
+		return "ok"
+	default: // want "closed switch over any must not have a default case"
+		return "other"
+	}
+}
+
+func Unknown(msg any) {
+	switch msg.(type) { //enumcheck:exhaustive=Ping|Pang // want "unknown type Pang" "missing cases default"
+	case Ping:
+	}
+}
+
+func Untyped(msg any) {
+	switch msg.(type) {
+	case Ping:
+	}
+}
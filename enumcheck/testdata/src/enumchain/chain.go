@@ -0,0 +1,187 @@
+// want package:"enumchain.Expr = {Add | Mul}, enumchain.Letter = {Alpha | Beta | Gamma}"
+package enumchain
+
+import "fmt"
+
+// Letter is an enumerated type.
+//
+//enumcheck:exhaustive
+type Letter byte
+
+const (
+	Alpha Letter = iota
+	Beta
+	Gamma
+)
+
+func IfChain(x Letter) {
+	if x == Alpha { // want "missing cases Gamma and default"
+		fmt.Println("alpha")
+	} else if x == Beta {
+		fmt.Println("beta")
+	}
+}
+
+func IfChainComplete(x Letter) {
+	if x == Alpha {
+		fmt.Println("alpha")
+	} else if Beta == x || x == Gamma {
+		fmt.Println("beta or gamma")
+	} else {
+		fmt.Println("default")
+	}
+}
+
+func IfChainLiteral(x Letter) {
+	if x == Alpha { // want "missing cases Beta, Gamma and default"
+		fmt.Println("alpha")
+	} else if x == 4 { // want "implicit conversion of 4 to enumchain.Letter"
+		fmt.Println("beta")
+	}
+}
+
+func IfChainOverride(x Letter) {
+	if x == Alpha { //enumcheck:relaxed
+		fmt.Println("alpha")
+	} else if x == Beta {
+		fmt.Println("beta")
+	} else if x == Gamma {
+		fmt.Println("gamma")
+	}
+}
+
+func IfChainSilent(x Letter) {
+	if x == Alpha { //enumcheck:silent
+		fmt.Println("alpha")
+	} else if x == Beta {
+		fmt.Println("beta")
+	}
+}
+
+func IfSingle(x Letter) {
+	if x == Alpha {
+		fmt.Println("alpha")
+	}
+}
+
+func IfDifferentSubjects(x, y Letter) {
+	if x == Alpha {
+		fmt.Println("alpha")
+	} else if y == Beta {
+		fmt.Println("beta")
+	}
+}
+
+func IfMixedConditions(x Letter, ok bool) {
+	if x == Alpha {
+		fmt.Println("alpha")
+	} else if ok {
+		fmt.Println("ok")
+	}
+}
+
+func IfNested(x, y Letter) {
+	if x == Alpha { // want "missing cases Gamma and default"
+		if y == Alpha { // want "missing cases Gamma"
+			fmt.Println("alpha")
+		} else if y == Beta { //enumcheck:ignore
+		} else {
+			fmt.Println("default")
+		}
+	} else if x == Beta {
+		fmt.Println("beta")
+	}
+}
+
+func TaglessSwitch(x Letter) {
+	switch { // want "missing cases Gamma"
+	case x == Alpha:
+		fmt.Println("alpha")
+	case x == Beta:
+		fmt.Println("beta")
+	default:
+		fmt.Println("default")
+	}
+}
+
+func TaglessSwitchList(x Letter) {
+	switch {
+	case x == Alpha:
+		fmt.Println("alpha")
+	case x == Beta, x == Gamma:
+		fmt.Println("beta or gamma")
+	default:
+		fmt.Println("default")
+	}
+}
+
+func TaglessSwitchOverride(x Letter) {
+	switch { //enumcheck:relaxed
+	case x == Alpha:
+		fmt.Println("alpha")
+	case x == Beta || x == Gamma:
+		fmt.Println("beta or gamma")
+	}
+}
+
+func TaglessSwitchOther(x Letter, ok bool) {
+	switch {
+	case x == Alpha:
+		fmt.Println("alpha")
+	case ok:
+		fmt.Println("ok")
+	}
+}
+
+// Expr is an enumerated type.
+//
+//enumcheck:exhaustive
+type Expr interface{}
+
+var _ Expr = Add{}
+var _ Expr = Mul{}
+
+type Add []Expr
+type Mul []Expr
+type Misc struct{}
+
+func TypeChain(x Expr) {
+	if v, ok := x.(Add); ok { // want "missing cases enumchain.Mul"
+		fmt.Println(v)
+	} else if _, ok := x.(Misc); ok { // want "implicit conversion of enumchain.Misc to enumchain.Expr"
+		fmt.Println("misc")
+	} else {
+		fmt.Println("default")
+	}
+}
+
+func TypeChainComplete(x Expr) {
+	if v, ok := x.(Add); ok {
+		fmt.Println(v)
+	} else if v, ok := x.(Mul); ok {
+		fmt.Println(v)
+	}
+}
+
+func IfVariables(got, want, other Letter) {
+	if got == want {
+		fmt.Println("want")
+	} else if got == other {
+		fmt.Println("other")
+	}
+}
+
+func IfConversion(x Letter) {
+	if x == Alpha { // want "missing cases Beta, Gamma and default"
+		fmt.Println("alpha")
+	} else if x == Letter(1) { // want `implicit conversion of Letter\(1\) to enumchain.Letter`
+		fmt.Println("beta")
+	}
+}
+
+func SwitchConversion(x Letter) {
+	switch x { // want "missing cases Beta, Gamma and default"
+	case Alpha:
+	case Letter(1): // want `implicit conversion of Letter\(1\) to enumchain.Letter`
+	}
+}
@@ -0,0 +1,105 @@
+// want package:"enumclosed.Expr = {Add | Value}, enumclosed.Letter = {Alpha | Beta | Gamma}"
+package enumclosed
+
+//enumcheck:closed
+type Letter byte
+
+const (
+	Alpha Letter = iota
+	Beta
+	Gamma
+)
+
+func Closed(x Letter) string {
+	switch x {
+	case Alpha:
+		return "alpha"
+	case Beta:
+		return "beta"
+	case Gamma:
+		return "gamma"
+	}
+	return ""
+}
+
+func Default(x Letter) string {
+	switch x {
+	case Alpha:
+		return "alpha"
+	case Beta, Gamma:
+		return "beta or gamma"
+	default: // want "closed switch over enumclosed.Letter must not have a default case"
+		return "unknown"
+	}
+}
+
+func DefaultFirst(x Letter) string {
+	switch x {
+	default: // want "closed switch over enumclosed.Letter must not have a default case"
+	case Alpha, Beta, Gamma:
+		return "letter"
+	}
+	return ""
+}
+
+func Missing(x Letter) string {
+	switch x { // want "missing cases Gamma"
+	case Alpha, Beta:
+		return "alpha or beta"
+	default: // want "closed switch over enumclosed.Letter must not have a default case"
+		return "unknown"
+	}
+}
+
+func Chain(x Letter) string {
+	switch {
+	case x == Alpha, x == Beta, x == Gamma:
+		return "letter"
+	default: // want "closed switch over enumclosed.Letter must not have a default case"
+		return "unknown"
+	}
+}
+
+func Complete(x Letter) string {
+	switch x { //enumcheck:complete
+	case Alpha:
+		return "alpha"
+	default:
+		return "unknown"
+	}
+}
+
+// Expr is an enumerated type.
+//
+//enumcheck:closed
+type Expr interface{}
+
+var _ Expr = Add{}
+var _ Expr = Value(0)
+
+type Add [2]Expr
+type Value float64
+
+func Type(x Expr) string {
+	switch x.(type) {
+	case Add:
+		return "add"
+	case Value:
+		return "value"
+	default: // want "closed switch over enumclosed.Expr must not have a default case"
+		return "unknown"
+	}
+}
+
+func Assign(x Letter) string {
+	name := ""
+	switch x {
+	case Alpha:
+		name = "alpha"
+	case Beta, Gamma:
+		name = "beta or gamma"
+	default: // want "closed switch over enumclosed.Letter must not have a default case"
+		name = "unknown"
+	}
+	return name
+}
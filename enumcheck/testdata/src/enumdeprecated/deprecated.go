@@ -0,0 +1,64 @@
+// want package:"enumdeprecated.Letter = {Alpha | Beta | Delta | Gamma}"
+package enumdeprecated
+
+import "fmt"
+
+// Letter is an enumerated type.
+//
+//enumcheck:exhaustive
+type Letter byte
+
+const (
+	Alpha Letter = iota
+	// Beta is the second letter.
+	//
+	// Deprecated: use Gamma instead.
+	Beta
+	Gamma
+	Delta //enumcheck:deprecated
+)
+
+func Switch(x Letter) {
+	switch x {
+	case Alpha, Gamma:
+		fmt.Println("letter")
+	default:
+		fmt.Println("default")
+	}
+}
+
+func SwitchDeprecated(x Letter) {
+	switch x {
+	case Alpha, Beta, Gamma, Delta:
+		fmt.Println("letter")
+	default:
+		fmt.Println("default")
+	}
+	if x == Beta {
+		fmt.Println("beta")
+	}
+}
+
+func SwitchMissing(x Letter) {
+	switch x { // want "missing cases Gamma"
+	case Alpha:
+		fmt.Println("letter")
+	default:
+		fmt.Println("default")
+	}
+}
+
+var names = map[Letter]string{
+	Alpha: "alpha",
+	Gamma: "gamma",
+}
+
+func Use(x Letter) {}
+
+func Uses() Letter {
+	var x Letter = Beta // want "use of deprecated enum value Beta"
+	x = Delta           // want "use of deprecated enum value Delta"
+	Use(Beta)           // want "use of deprecated enum value Beta"
+	_ = x
+	return Delta // want "use of deprecated enum value Delta"
+}
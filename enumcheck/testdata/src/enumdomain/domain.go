@@ -0,0 +1,92 @@
+// want package:"enumdomain.Letter = {Alpha | Beta | Gamma}"
+package enumdomain
+
+import "sync/atomic"
+
+//enumcheck:exhaustive
+type Letter int32
+
+const (
+	Alpha Letter = iota
+	Beta
+	Gamma
+)
+
+type Message struct {
+	Kind int32 //enumcheck:of=Letter // want Kind:"of enumdomain.Letter"
+	Text string
+}
+
+type Machine struct {
+	state atomic.Int32 //enumcheck:of=Letter // want state:"of enumdomain.Letter"
+	last  int32        //enumcheck:of=Missing // want "unknown enum Missing"
+}
+
+//enumcheck:of=Letter
+var current int32 // want current:"of enumdomain.Letter"
+
+func Handle(m *Message) string {
+	switch m.Kind { // want "missing cases Gamma"
+	case int32(Alpha):
+		return "alpha"
+	case int32(Beta), 7: // want "implicit conversion of 7 to enumdomain.Letter"
+		return "beta"
+	default:
+		return "?"
+	}
+}
+
+func Compare(m *Message) bool {
+	if m.Kind == int32(Gamma) {
+		return true
+	}
+	return m.Kind != 3 // want "3 is not a value of enumdomain.Letter"
+}
+
+func Store(m *Message, letter Letter, n int32) {
+	m.Kind = int32(letter)
+	m.Kind = current
+	m.Kind = n // want "n is not a value of enumdomain.Letter"
+	current = int32(Beta)
+
+	var machine Machine
+	machine.state.Store(int32(Alpha))
+	machine.state.Store(n)                        // want "n is not a value of enumdomain.Letter"
+	machine.state.CompareAndSwap(int32(Alpha), 2) // want "2 is not a value of enumdomain.Letter"
+	atomic.StoreInt32(&current, 4)                // want "4 is not a value of enumdomain.Letter"
+
+	switch machine.state.Load() {
+	case int32(Alpha), int32(Beta), int32(Gamma):
+	case n: // want "n is not a value of enumdomain.Letter"
+	default:
+	}
+
+	_ = Message{Kind: int32(Gamma)}
+	_ = Message{5, "five"} // want "5 is not a value of enumdomain.Letter"
+}
+
+func Arithmetic(m *Message) {
+	var machine Machine
+	machine.state.Add(1)         // want "arithmetic on machine.state, which holds values of enumdomain.Letter"
+	machine.state.And(1)         // want "arithmetic on machine.state, which holds values of enumdomain.Letter"
+	machine.state.Or(2)          // want "arithmetic on machine.state, which holds values of enumdomain.Letter"
+	atomic.AddInt32(&current, 1) // want "arithmetic on current, which holds values of enumdomain.Letter"
+	atomic.AddInt32(&machine.last, 1)
+	m.Kind++              // want "arithmetic on m.Kind, which holds values of enumdomain.Letter"
+	m.Kind += 9           // want "arithmetic on m.Kind, which holds values of enumdomain.Letter"
+	m.Kind |= int32(Beta) // want "arithmetic on m.Kind, which holds values of enumdomain.Letter"
+	current--             // want "arithmetic on current, which holds values of enumdomain.Letter"
+	_ = machine.state.Load() + 1
+}
+
+// Set sets the kind of a message.
+//
+//enumcheck:of=Letter kind
+func Set(m *Message, kind int32) { // want kind:"of enumdomain.Letter"
+	m.Kind = kind
+}
+
+func Call(m *Message) {
+	Set(m, int32(Beta))
+	Set(m, 9) // want "9 is not a value of enumdomain.Letter"
+}
@@ -0,0 +1,53 @@
+// want package:"enumexcept.Letter = {Alpha | Beta | Gamma | Delta}"
+package enumexcept
+
+//enumcheck:exhaustive
+type Letter byte
+
+const (
+	Alpha Letter = iota
+	Beta
+	Gamma
+	Delta
+)
+
+func Excepted(x Letter) {
+	switch x { //enumcheck:exhaustive,except=Gamma,Delta reason="handled upstream"
+	case Alpha:
+	case Beta:
+	default:
+	}
+}
+
+func ExceptedRelaxed(x Letter) {
+	switch x { //enumcheck:relaxed,except=Delta reason="not reachable from the parser"
+	case Alpha, Beta, Gamma:
+	}
+}
+
+func ExceptedMode(x Letter) {
+	switch x { //enumcheck:except=Delta reason=legacy // want "missing cases default"
+	case Alpha, Beta, Gamma:
+	}
+}
+
+func ExceptedMissing(x Letter) {
+	switch x { //enumcheck:exhaustive,except=Gamma reason="handled upstream" // want "missing cases Delta"
+	case Alpha, Beta:
+	default:
+	}
+}
+
+func Stale(x Letter) {
+	switch x { //enumcheck:exhaustive,except=Gamma,Epsilon reason="handled upstream" // want "excepted value Gamma is handled" "excepted value Epsilon is not a member of enumexcept.Letter" "missing cases Delta"
+	case Alpha, Beta, Gamma:
+	default:
+	}
+}
+
+func NoReason(x Letter) {
+	switch x { //enumcheck:exhaustive,except=Delta // want "except requires a reason"
+	case Alpha, Beta, Gamma:
+	default:
+	}
+}
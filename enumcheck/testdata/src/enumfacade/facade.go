@@ -0,0 +1,28 @@
+package enumfacade
+
+import "enumbyte"
+
+// Letter re-exports enumbyte.Letter.
+type Letter = enumbyte.Letter
+
+const (
+	Alpha = enumbyte.Alpha // want Alpha:"enumbyte.Alpha"
+	Beta  = enumbyte.Beta  // want Beta:"enumbyte.Beta"
+	Gamma = enumbyte.Gamma // want Gamma:"enumbyte.Gamma"
+	Delta = enumbyte.Delta // want Delta:"enumbyte.Delta"
+)
+
+var Eta = enumbyte.Eta // want Eta:"enumbyte.Eta"
+
+func Name(x Letter) string {
+	switch x {
+	case Alpha:
+		return "alpha"
+	case Beta, Gamma:
+		return "beta or gamma"
+	case Delta, Eta:
+		return "delta or eta"
+	default:
+		return ""
+	}
+}
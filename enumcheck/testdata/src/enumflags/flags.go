@@ -0,0 +1,72 @@
+// want package:"enumflags.Perm = {Exec | None | Read | ReadWrite | Write}, enumflags.Style = {Bold | Broken | Italic | Large | Loud | Strong}"
+package enumflags
+
+import "fmt"
+
+// Perm is an enumerated type.
+//
+//enumcheck:flags
+type Perm uint8
+
+const (
+	Read Perm = 1 << iota
+	Write
+	Exec
+
+	None      Perm = 0            //enumcheck:composite
+	ReadWrite      = Read | Write //enumcheck:composite
+)
+
+func Combine() {
+	var p Perm = Read | Write
+	p = p | Exec
+	p = p &^ Write
+	p = p & ReadWrite
+	p = 3
+	p = 0
+	p = 8         // want "8 has bits outside of enumflags.Perm"
+	p = Read | 64 // want "Read [|] 64 has bits outside of enumflags.Perm"
+	Use(Read | Exec)
+	Use(0x10) // want "0x10 has bits outside of enumflags.Perm"
+	_ = p
+}
+
+func Use(p Perm) {}
+
+func Return() (p Perm) {
+	return Perm(128) // want "Perm[(]128[)] has bits outside of enumflags.Perm"
+}
+
+func Switch(p Perm) {
+	switch p {
+	case Read:
+		fmt.Println("read")
+	case Read | Write:
+		fmt.Println("read write")
+	case 16: // want "16 has bits outside of enumflags.Perm"
+		fmt.Println("invalid")
+	}
+}
+
+func SwitchExhaustive(p Perm) {
+	switch p { //enumcheck:exhaustive // want "missing cases Exec, None, Read, ReadWrite, Write and default"
+	}
+}
+
+// Style is an enumerated type.
+//
+//enumcheck:flags
+type Style uint16
+
+const (
+	Bold   Style = 1
+	Italic Style = 2
+	Large  Style = 3                 // want "flag Large is not a single bit"
+	Strong Style = 1                 // want "flag Strong duplicates Bold"
+	Loud   Style = Bold | Italic | 8 //enumcheck:composite // want "composite Loud has bits outside of enumflags.Style"
+	Broken Style = 0                 // want "flag Broken is not a single bit"
+)
+
+func ReturnUnnamed() Perm {
+	return Perm(64) // want "Perm[(]64[)] has bits outside of enumflags.Perm"
+}
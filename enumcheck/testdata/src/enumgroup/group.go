@@ -0,0 +1,102 @@
+// want package:"enumgroup.Day = {Monday | Tuesday | Wednesday | Thursday | Friday | Saturday | Sunday}, enumgroup.Expr = {Add | Mul | Neg | Value}"
+package enumgroup
+
+//enumcheck:exhaustive
+type Day int
+
+const (
+	Monday Day = iota
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday //enumcheck:group=weekend
+	Sunday   //enumcheck:group=weekend
+)
+
+func IsWeekend(d Day) bool {
+	switch d { // want "missing cases Friday, Monday, Sunday, Thursday, Tuesday, Wednesday and default"
+	case Saturday:
+		return true
+	}
+	return false
+}
+
+func WeekendChore(d Day) string {
+	switch d { //enumcheck:subset=weekend
+	case Saturday:
+		return "laundry"
+	case Sunday:
+		return "groceries"
+	}
+	return ""
+}
+
+func MissingChore(d Day) string {
+	switch d { //enumcheck:subset=weekend // want "missing cases Sunday"
+	case Saturday:
+		return "laundry"
+	}
+	return ""
+}
+
+func UnexpectedChore(d Day) string {
+	switch d { //enumcheck:subset=weekend
+	case Saturday, Sunday:
+		return "rest"
+	case Monday: // want "unexpected case Monday, not in group weekend"
+		return "work"
+	}
+	return ""
+}
+
+func ExhaustiveChore(d Day) string {
+	switch d { //enumcheck:exhaustive,subset=weekend // want "missing cases default"
+	case Saturday, Sunday:
+		return "rest"
+	}
+	return ""
+}
+
+func UnknownGroup(d Day) string {
+	switch d { //enumcheck:subset=holiday // want "unknown group holiday for enumgroup.Day"
+	case Saturday:
+		return "rest"
+	}
+	return ""
+}
+
+// Expr is an enumerated type.
+//
+//enumcheck:exhaustive
+type Expr interface{}
+
+var _ Expr = Add{} //enumcheck:group=binary
+var _ Expr = Mul{} //enumcheck:group=binary
+var _ Expr = Neg{}
+var _ Expr = Value(0)
+
+type Add [2]Expr
+type Mul [2]Expr
+type Neg [1]Expr
+type Value float64
+
+func Operator(x Expr) string {
+	switch x.(type) { //enumcheck:subset=binary
+	case Add:
+		return "+"
+	case Mul:
+		return "*"
+	}
+	return ""
+}
+
+func MissingOperator(x Expr) string {
+	switch x.(type) { //enumcheck:subset=binary // want "missing cases enumgroup.Mul"
+	case Add:
+		return "+"
+	case Neg: // want "unexpected case enumgroup.Neg, not in group binary"
+		return "-"
+	}
+	return ""
+}
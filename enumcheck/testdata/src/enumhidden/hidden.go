@@ -0,0 +1,50 @@
+// want package:"enumhidden.Event = {Start | Stop | tick}, enumhidden.State = {Idle | Running | Done | retrying}"
+package enumhidden
+
+// State is an enumerated type with an internal state.
+//
+//enumcheck:exhaustive
+type State byte
+
+const (
+	Idle State = iota
+	Running
+	Done
+
+	retrying
+)
+
+func Internal(s State) string {
+	switch s { // want "missing cases retrying and default"
+	case Idle:
+		return "idle"
+	case Running:
+		return "running"
+	case Done:
+		return "done"
+	}
+	return ""
+}
+
+// Event is an enumerated type with an internal event.
+//
+//enumcheck:exhaustive
+type Event interface{}
+
+var _ Event = Start{}
+var _ Event = Stop{}
+var _ Event = tick{}
+
+type Start struct{}
+type Stop struct{}
+type tick struct{}
+
+func InternalEvent(e Event) string {
+	switch e.(type) { // want "missing cases enumhidden.tick"
+	case Start:
+		return "start"
+	case Stop:
+		return "stop"
+	}
+	return ""
+}
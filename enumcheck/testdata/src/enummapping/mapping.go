@@ -0,0 +1,87 @@
+// want package:"enummapping.Status = {Active | Deleted | Inactive}, enummapping.dbStatus = {dbActive | dbDeleted | dbInactive | dbUnknown}"
+package enummapping
+
+import "enumbyte"
+
+// Status is an enumerated type.
+//
+//enumcheck:complete
+type Status byte
+
+const (
+	Active Status = iota
+	Inactive
+	Deleted
+)
+
+// dbStatus is an enumerated type.
+//
+//enumcheck:relaxed
+type dbStatus string
+
+const (
+	dbUnknown  dbStatus = ""
+	dbActive   dbStatus = "active"
+	dbInactive dbStatus = "inactive"
+	dbDeleted  dbStatus = "deleted"
+)
+
+//enumcheck:mapping
+func toDB(s Status) dbStatus {
+	switch s { // want "missing cases Deleted"
+	case Active:
+		return dbActive
+	case Inactive:
+		return dbStatus("invalid") // want "dbStatus[(]\"invalid\"[)] is not a value of enummapping.dbStatus"
+	default:
+		return dbUnknown
+	}
+}
+
+//enumcheck:mapping=bijective
+func toDBBijective(s Status) dbStatus { // want "toDBBijective never produces dbUnknown"
+	switch s {
+	case Active:
+		return dbActive
+	case Inactive:
+		return dbInactive
+	case Deleted:
+		return dbDeleted
+	}
+	return dbActive // want "dbActive is produced more than once"
+}
+
+//enumcheck:mapping
+func toLetter(s Status) (enumbyte.Letter, bool) {
+	switch s {
+	case Active:
+		return enumbyte.Alpha, true
+	case Inactive:
+		return enumbyte.Beta, true
+	case Deleted:
+		return enumbyte.Letter(9), false // want "enumbyte.Letter[(]9[)] is not a value of enumbyte.Letter"
+	}
+	return enumbyte.Alpha, false
+}
+
+//enumcheck:mapping
+func fromDB(s dbStatus) Status { // want "mapping fromDB does not switch on s"
+	if s == dbActive {
+		return Active
+	}
+	return Inactive
+}
+
+//enumcheck:mapping
+func invalid(s string) Status { // want "mapping invalid must convert an enum parameter to an enum result"
+	return Active
+}
+
+func notMapping(s Status) dbStatus {
+	switch s {
+	case Active:
+		return dbActive
+	default:
+		return dbUnknown
+	}
+}
@@ -0,0 +1,9 @@
+package client
+
+import "enummutation"
+
+func Reassign() {
+	enummutation.True = enummutation.False    // want "assignment to enum value True"
+	enummutation.Monday = enummutation.Friday // want "assignment to enum value Monday"
+	enummutation.True.Set("yes")              // want "mutation of enum value True through pointer method Set"
+}
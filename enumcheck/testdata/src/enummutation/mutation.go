@@ -0,0 +1,65 @@
+// want package:"enummutation.Day = {Friday | Monday}, enummutation.Option = {False | Maybe | True}"
+package enummutation
+
+// Option is an enumerated type.
+//
+//enumcheck:exhaustive
+type Option struct{ value string }
+
+var (
+	True  = Option{"true"}
+	False = Option{"false"}
+	Maybe = &Option{"maybe"}
+)
+
+// Day is an enumerated type.
+//
+//enumcheck:relaxed
+type Day string
+
+var (
+	Monday = Day("monday")
+	Friday = Day("friday")
+)
+
+var current = &Monday
+
+func init() {
+	Monday = Day("Monday")
+	True.value = "TRUE"
+}
+
+func Reassign() {
+	True = False                    // want "assignment to enum value True"
+	Monday, Friday = Friday, Monday // want "assignment to enum value Monday" "assignment to enum value Friday"
+	Monday += Friday                // want "assignment to enum value Monday"
+	x := True
+	x = False
+	_ = x
+}
+
+func Mutate() {
+	True.value = "yes"   // want "mutation of enum value True"
+	(False).value = "no" // want "mutation of enum value False"
+	Maybe.value = "?"    // want "mutation of enum value Maybe"
+	*Maybe = Option{"!"} // want "mutation of enum value Maybe" "invalid enum for enummutation.Option"
+	p := &True           // want "address of enum value True"
+	q := &True.value     // want "mutation of enum value True"
+	_, _ = p, q
+	m := Maybe
+	_ = m
+}
+
+func (o *Option) Set(value string) { o.value = value }
+
+func (o Option) String() string { return o.value }
+
+func PointerMethod() {
+	True.Set("yes")    // want "mutation of enum value True through pointer method Set"
+	set := False.Set   // want "mutation of enum value False through pointer method Set"
+	Maybe.Set("maybe") // want "mutation of enum value Maybe through pointer method Set"
+	_ = True.String()
+	x := True
+	x.Set("copy")
+	_ = set
+}
@@ -0,0 +1,72 @@
+// want package:"enumnonzero.Day = {Empty | Friday | Monday}, enumnonzero.Letter = {Alpha | Beta | Gamma}, enumnonzero.Option = {False | None | True}"
+package enumnonzero
+
+// Letter is an enumerated type.
+//
+//enumcheck:exhaustive,nonzero
+type Letter byte
+
+const (
+	Alpha Letter = iota + 1
+	Beta
+	Gamma
+)
+
+// Day is an enumerated type.
+//
+//enumcheck:relaxed,nonzero
+type Day string
+
+const (
+	Monday = Day("monday")
+	Friday = Day("friday")
+	Empty  = Day("") // want "value Empty equals the zero value of enumnonzero.Day"
+)
+
+// Option is an enumerated type.
+//
+//enumcheck:nonzero
+type Option struct{ value string }
+
+var (
+	True  = Option{"true"}
+	False = Option{value: "false"}
+	None  = Option{} // want "value None equals the zero value of enumnonzero.Option"
+)
+
+type Config struct {
+	Name   string
+	Letter Letter
+}
+
+type Nested struct {
+	Config Config
+}
+
+type Optional struct {
+	Option *Option
+}
+
+func Declarations() {
+	var x Letter // want "zero value of enumnonzero.Letter is not a valid enumnonzero.Letter"
+	var y Letter = Alpha
+	var o Option // want "zero value of enumnonzero.Option is not a valid enumnonzero.Option"
+	var c Config // want "zero value of enumnonzero.Config is not a valid enumnonzero.Letter"
+	var p *Option
+	_, _, _, _, _ = x, y, o, c, p
+}
+
+func Literals() {
+	_ = Config{Name: "x"} // want "missing field Letter, zero value is not a valid enumnonzero.Letter"
+	_ = Config{}          // want "missing field Letter, zero value is not a valid enumnonzero.Letter"
+	_ = Config{Letter: Beta}
+	_ = Config{"x", Gamma}
+	_ = Nested{} // want "missing field Config, zero value is not a valid enumnonzero.Letter"
+	_ = Optional{}
+}
+
+func New() {
+	_ = new(Letter) // want "zero value of enumnonzero.Letter is not a valid enumnonzero.Letter"
+	_ = new(Config) // want "zero value of enumnonzero.Config is not a valid enumnonzero.Letter"
+	_ = new(Optional)
+}
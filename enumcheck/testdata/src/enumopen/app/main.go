@@ -0,0 +1,39 @@
+package main
+
+import (
+	"enumopen"
+	"enumopen/gzip"
+	"enumopen/zstd"
+)
+
+func Name(c enumopen.Codec) string {
+	switch c.(type) {
+	case enumopen.Raw:
+		return "raw"
+	case gzip.Codec:
+		return "gzip"
+	case zstd.Codec:
+		return "zstd"
+	}
+	return ""
+}
+
+func Missing(c enumopen.Codec) string {
+	switch c.(type) { // want "missing cases enumopen/gzip.Codec and enumopen/zstd.Codec"
+	case enumopen.Raw:
+		return "raw"
+	}
+	return ""
+}
+
+func KindName(k enumopen.Kind) string {
+	switch k {
+	case enumopen.KindRaw:
+		return "raw"
+	case gzip.KindGzip:
+		return "gzip"
+	}
+	return ""
+}
+
+func main() {}
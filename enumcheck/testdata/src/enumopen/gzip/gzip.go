@@ -0,0 +1,29 @@
+// want package:"enumopen.Codec [+]= {enumopen/gzip.Codec}, enumopen.Kind [+]= {KindGzip}"
+package gzip
+
+import "enumopen"
+
+var _ enumopen.Codec = Codec{}
+
+type Codec struct{}
+
+const KindGzip enumopen.Kind = 1
+
+func Name(c enumopen.Codec) string {
+	switch c.(type) { // want "missing default, open enum enumopen.Codec may have types in packages not imported here"
+	case Codec:
+		return "gzip"
+	case enumopen.Raw:
+		return "raw"
+	}
+	return ""
+}
+
+func KindName(k enumopen.Kind) string {
+	switch k { // want "missing cases KindGzip"
+	case enumopen.KindRaw:
+		return "raw"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,28 @@
+package lib
+
+import (
+	"enumopen"
+	"enumopen/gzip"
+)
+
+// Name doesn't import enumopen/zstd, so it cannot handle zstd.Codec.
+func Name(c enumopen.Codec) string {
+	switch c.(type) { // want "missing default, open enum enumopen.Codec may have types in packages not imported here"
+	case enumopen.Raw:
+		return "raw"
+	case gzip.Codec:
+		return "gzip"
+	}
+	return ""
+}
+
+func NameDefault(c enumopen.Codec) string {
+	switch c.(type) {
+	case enumopen.Raw:
+		return "raw"
+	case gzip.Codec:
+		return "gzip"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,35 @@
+// want package:"enumopen.Codec = {Raw}, enumopen.Kind = {KindRaw}"
+package enumopen
+
+// Codec is implemented by plugins.
+//
+//enumcheck:relaxed,open
+type Codec interface{}
+
+var _ Codec = Raw{}
+
+type Raw struct{}
+
+// Kind is extended by plugins.
+//
+//enumcheck:relaxed,open
+type Kind byte
+
+const KindRaw Kind = 0
+
+func Name(c Codec) string {
+	switch c.(type) { // want "missing default, open enum enumopen.Codec may have types in packages not imported here"
+	case Raw:
+		return "raw"
+	}
+	return ""
+}
+
+func KindName(k Kind) string {
+	switch k {
+	case KindRaw:
+		return "raw"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,11 @@
+package plain
+
+import "enumopen"
+
+func Name(c enumopen.Codec) string {
+	switch c.(type) { // want "missing default, open enum enumopen.Codec may have types in packages not imported here"
+	case enumopen.Raw:
+		return "raw"
+	}
+	return ""
+}
@@ -0,0 +1,8 @@
+// want package:"enumopen.Codec [+]= {enumopen/zstd.Codec}"
+package zstd
+
+import "enumopen"
+
+var _ enumopen.Codec = Codec{}
+
+type Codec struct{}
@@ -0,0 +1,30 @@
+// want package:"enumpointer.Option = {False | Maybe | True}"
+package enumpointer
+
+import "fmt"
+
+// Option is an enumerated type.
+//
+//enumcheck:exhaustive
+type Option struct{ value string }
+
+var (
+	True  = &Option{"true"}
+	False = &Option{"false"}
+	Maybe = &Option{value: "maybe"}
+)
+
+func Switch(x *Option) {
+	switch x { // want "missing cases False and Maybe"
+	case True:
+		fmt.Println("true")
+	default:
+		fmt.Println("default")
+	}
+}
+
+func Construct() *Option {
+	x := &Option{"maybe?"} // want "invalid enum for enumpointer.Option"
+	_ = x
+	return &Option{"no"} // want "invalid enum for enumpointer.Option"
+}
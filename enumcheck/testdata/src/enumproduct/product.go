@@ -0,0 +1,105 @@
+// want package:"enumproduct.Digit = {D0 | D1 | D2 | D3 | D4 | D5 | D6 | D7 | D8 | D9}, enumproduct.Phase = {Done | Idle | Running}"
+package enumproduct
+
+//enumcheck:exhaustive
+type Phase byte
+
+const (
+	Idle Phase = iota
+	Running
+	Done
+)
+
+func Pairs(from, to Phase) bool {
+	switch [2]Phase{from, to} { //enumcheck:product // want `missing cases \(\*, Done\), \(Running, \*\) and \(Done, \*\)`
+	case [2]Phase{Idle, Idle}, [2]Phase{Idle, Running}:
+		return true
+	}
+	return false
+}
+
+type transition struct {
+	From, To Phase
+}
+
+func Transitions(from, to Phase) bool {
+	switch (transition{from, to}) { //enumcheck:product // want `missing cases \(\*, Done\)`
+	case transition{Idle, Idle}, transition{Idle, Running}:
+		return true
+	case transition{From: Running, To: Idle}, transition{From: Running, To: Running}:
+		return true
+	case transition{To: Idle, From: Done}, transition{Done, Running}:
+		return true
+	}
+	return false
+}
+
+func Complete(from, to Phase) bool {
+	switch (transition{from, to}) { //enumcheck:complete,product
+	case transition{Idle, Running}:
+		return true
+	default:
+		return false
+	}
+}
+
+func Exhaustive(from, to Phase) bool {
+	switch (transition{from, to}) { //enumcheck:exhaustive,product // want `missing cases \(\*, \*\) and default`
+	}
+	return false
+}
+
+type mixed struct {
+	Phase Phase
+	Count int
+}
+
+func Invalid(from Phase, to int) bool {
+	switch (mixed{from, to}) { //enumcheck:product // want `enumcheck:product requires an array or a struct of enums, got enumproduct.mixed`
+	}
+	return false
+}
+
+var pending = transition{Idle, Idle}
+
+func Variable(from, to Phase) bool {
+	switch (transition{from, to}) { //enumcheck:product
+	case pending: // want `enumcheck:product cannot verify case, it must be a literal of enum values`
+		return true
+	case transition{Idle, Running}:
+		return true
+	}
+	return false
+}
+
+func Unknown(from, to Phase, x int) bool {
+	switch (transition{from, to}) { //enumcheck:product
+	case transition{Idle, Phase(x)}: // want `enumcheck:product cannot verify case, it must be a literal of enum values`
+		return true
+	}
+	return false
+}
+
+//enumcheck:exhaustive
+type Digit byte
+
+const (
+	D0 Digit = iota
+	D1
+	D2
+	D3
+	D4
+	D5
+	D6
+	D7
+	D8
+	D9
+)
+
+func Large(digits [4]Digit) bool {
+	switch digits { //enumcheck:product // want `enumcheck:product has too many combinations to verify`
+	case [4]Digit{D0, D0, D0, D0}:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+// want package:"enumreturn.Day = {Friday | Monday}, enumreturn.Letter = {Alpha | Beta}"
+package enumreturn
+
+// Letter is an enumerated type.
+//
+//enumcheck:exhaustive
+type Letter byte
+
+const (
+	Alpha Letter = iota
+	Beta
+)
+
+// Day is an enumerated type.
+//
+//enumcheck:relaxed
+type Day string
+
+const (
+	Monday Day = "monday"
+	Friday Day = "friday"
+)
+
+func Unnamed() Letter {
+	return 3 // want "implicit conversion of 3 to enumreturn.Letter"
+}
+
+func Named() (letter Letter) {
+	return 3 // want "implicit conversion of 3 to enumreturn.Letter"
+}
+
+func Valid() Letter {
+	return Beta
+}
+
+func Relaxed() Day {
+	return "sunday" // want `implicit conversion of "sunday" to enumreturn.Day`
+}
+
+func Multiple() (Day, int, Letter) {
+	return Friday, 1, 2 // want "implicit conversion of 2 to enumreturn.Letter"
+}
+
+func Tuple() (Day, Letter) {
+	return Multiple2()
+}
+
+func Multiple2() (Day, Letter) {
+	return Monday, Alpha
+}
@@ -0,0 +1,74 @@
+// want package:"enumsentinel.Letter = {Alpha | Beta | Gamma}"
+package enumsentinel
+
+import "fmt"
+
+// Letter is an enumerated type.
+//
+//enumcheck:exhaustive
+type Letter byte
+
+const (
+	LetterUnknown Letter = iota //enumcheck:sentinel
+	Alpha
+	Beta
+	Gamma
+	numLetters //enumcheck:sentinel
+)
+
+var letterNames = [numLetters]string{"", "alpha", "beta", "gamma"}
+
+var letterTable = map[Letter]string{
+	Alpha: "alpha",
+	Beta:  "beta",
+	Gamma: "gamma",
+}
+
+func Switch(x Letter) {
+	switch x {
+	case Alpha, Beta, Gamma:
+		fmt.Println("letter")
+	default:
+		fmt.Println("default")
+	}
+}
+
+func SwitchSentinel(x Letter) {
+	switch x {
+	case Alpha, Beta, Gamma:
+		fmt.Println("letter")
+	case LetterUnknown: // want "sentinel LetterUnknown used as a value"
+		fmt.Println("unknown")
+	default:
+		fmt.Println("default")
+	}
+}
+
+func Loop() {
+	for x := Alpha; x < numLetters; x++ {
+		fmt.Println(letterNames[x])
+	}
+	for x := range numLetters {
+		fmt.Println(x)
+	}
+	names := make([]string, numLetters)
+	_ = names
+	_ = int(numLetters) - 1
+}
+
+func Assign() {
+	var x Letter = numLetters // want "sentinel numLetters used as a value"
+	x = (LetterUnknown)       // want "sentinel LetterUnknown used as a value"
+	_ = x
+}
+
+func Return() Letter {
+	return numLetters // want "sentinel numLetters used as a value"
+}
+
+func Use(x Letter) {}
+
+func Call() {
+	Use(numLetters)                    // want "sentinel numLetters used as a value"
+	_ = []Letter{Alpha, LetterUnknown} // want "sentinel LetterUnknown used as a value"
+}
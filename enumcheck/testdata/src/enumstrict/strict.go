@@ -0,0 +1,181 @@
+// want package:"enumstrict.Letter = {Alpha | Beta}, enumstrict.Shape = {Circle | Square}"
+package enumstrict
+
+import (
+	"errors"
+	"fmt"
+	"log"
+)
+
+//enumcheck:exhaustive,strict
+type Letter byte
+
+const (
+	Alpha Letter = iota
+	Beta
+)
+
+//enumcheck:unreachable
+func unreachable(v any) { // want unreachable:"unreachable"
+	log.Printf("unreachable %v", v)
+}
+
+func Panic(x Letter) {
+	switch x {
+	case Alpha, Beta:
+	default:
+		panic(fmt.Sprintf("invalid letter %v", x))
+	}
+}
+
+func Error(x Letter) (string, error) {
+	switch x {
+	case Alpha:
+		return "alpha", nil
+	case Beta:
+		return "beta", nil
+	default:
+		return "", errors.New("invalid letter")
+	}
+}
+
+func Fatal(x Letter) {
+	switch x {
+	case Alpha, Beta:
+	default:
+		log.Fatalf("invalid letter %v", x)
+	}
+}
+
+func Unreachable(x Letter) {
+	switch x {
+	case Alpha, Beta:
+	default:
+		unreachable(x)
+	}
+}
+
+func Branches(x Letter, verbose bool) error {
+	switch x {
+	case Alpha, Beta:
+	default:
+		if verbose {
+			panic("invalid letter")
+		}
+		return fmt.Errorf("invalid letter %v", x)
+	}
+	return nil
+}
+
+func Break(x Letter) {
+	switch x {
+	case Alpha, Beta:
+	default: // want "default case must fail: panic, return an error or call an unreachable function"
+		break
+	}
+}
+
+func Log(x Letter) {
+	switch x {
+	case Alpha, Beta:
+	default: // want "default case must fail: panic, return an error or call an unreachable function"
+		log.Printf("invalid letter %v", x)
+	}
+}
+
+func NilError(x Letter) error {
+	switch x {
+	case Alpha, Beta:
+	default: // want "default case must fail: panic, return an error or call an unreachable function"
+		return nil
+	}
+	return nil
+}
+
+func SomeBranches(x Letter, verbose bool) {
+	switch x {
+	case Alpha, Beta:
+	default: // want "default case must fail: panic, return an error or call an unreachable function"
+		if verbose {
+			panic("invalid letter")
+		}
+	}
+}
+
+func Relaxed(x Letter) {
+	switch x { //enumcheck:relaxed
+	case Alpha, Beta:
+	default:
+	}
+}
+
+func Chain(x Letter) {
+	switch {
+	case x == Alpha, x == Beta:
+	default: // want "default case must fail: panic, return an error or call an unreachable function"
+		break
+	}
+}
+
+func ChainPanic(x Letter) {
+	switch {
+	case x == Alpha, x == Beta:
+	default:
+		panic("invalid letter")
+	}
+}
+
+func Else(x Letter) string {
+	if x == Alpha {
+		return "alpha"
+	} else if x == Beta {
+		return "beta"
+	} else { // want "default case must fail: panic, return an error or call an unreachable function"
+		log.Printf("invalid letter %v", x)
+	}
+	return ""
+}
+
+func ElseError(x Letter) (string, error) {
+	if x == Alpha {
+		return "alpha", nil
+	} else if x == Beta {
+		return "beta", nil
+	} else {
+		return "", fmt.Errorf("invalid letter %v", x)
+	}
+}
+
+// Shape is an enumerated type.
+//
+//enumcheck:exhaustive,strict
+type Shape interface{}
+
+var _ Shape = Circle{}
+var _ Shape = Square{}
+
+type Circle struct{}
+type Square struct{}
+
+func Type(s Shape) {
+	switch s.(type) {
+	case Circle, Square:
+	default: // want "default case must fail: panic, return an error or call an unreachable function"
+	}
+}
+
+func TypePanic(s Shape) {
+	switch s.(type) {
+	case Circle, Square:
+	default:
+		unreachable(s)
+	}
+}
+
+func TypeOnlyDefault(s Shape) {
+	unreachable(s)
+	switch s.(type) { //enumcheck:exhaustive // want "missing cases enumstrict.Circle and enumstrict.Square"
+	default: // want "default case must fail: panic, return an error or call an unreachable function"
+		log.Printf("shape %v", s)
+	}
+}
@@ -0,0 +1,105 @@
+// want package:"enumstringer.Day = {Monday | Sunday | Tuesday}, enumstringer.Letter = {Alpha | Beta | Gamma}"
+package enumstringer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Letter is an enumerated type.
+//
+//enumcheck:silent
+type Letter byte
+
+const (
+	Alpha Letter = iota
+	Beta
+	Gamma
+)
+
+func (l Letter) String() string {
+	switch l { // want "missing cases Gamma"
+	case Alpha:
+		return "alpha"
+	case Beta:
+		return "beta"
+	default:
+		return fmt.Sprintf("Letter(%d)", l)
+	}
+}
+
+func (l *Letter) MarshalText() ([]byte, error) {
+	switch *l { // want "missing cases Beta and Gamma"
+	case Alpha:
+		return []byte("alpha"), nil
+	}
+	return nil, errors.New("invalid letter")
+}
+
+func (l Letter) Name() string {
+	switch l {
+	case Alpha:
+		return "alpha"
+	}
+	return ""
+}
+
+//enumcheck:produces Letter
+func ParseLetter(s string) (Letter, error) { // want "ParseLetter never produces Gamma"
+	switch s {
+	case "alpha":
+		return Alpha, nil
+	case "beta":
+		return Beta, nil
+	}
+	return Alpha, errors.New("invalid letter")
+}
+
+//enumcheck:produces Letter
+func LetterFromByte(b byte) Letter {
+	switch b {
+	case 'a':
+		return Alpha
+	case 'b':
+		return Beta
+	}
+	fn := func() Letter { return Alpha }
+	_ = fn
+	return Gamma
+}
+
+//enumcheck:produces Day
+func ParseInvalid(s string) Letter { // want "ParseInvalid does not return enum Day"
+	return Alpha
+}
+
+// Day is an enumerated type.
+//
+//enumcheck:complete
+type Day string
+
+const (
+	Monday  = Day("monday")
+	Tuesday = Day("tuesday")
+	Sunday  = Day("sunday")
+)
+
+func (d Day) String() string {
+	switch d { // want "missing cases Sunday"
+	case Monday:
+		return "Monday"
+	case Tuesday:
+		return "Tuesday"
+	default:
+		return "unknown"
+	}
+}
+
+func (d Day) MarshalText() ([]byte, error) {
+	switch d { //enumcheck:complete
+	case Monday:
+		return []byte("monday"), nil
+	default:
+		return []byte(d), nil
+	}
+}
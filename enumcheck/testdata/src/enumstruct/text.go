@@ -26,3 +26,15 @@ func DayNonExhaustive() {
 		fmt.Println("default")
 	}
 }
+
+func Construct() Option {
+	var x Option = Option{"maybe?"} // want "invalid enum for enumstruct.Option"
+	x = Option{"maybe?"}            // want "invalid enum for enumstruct.Option"
+	if x == (Option{"maybe?"}) {    // want "invalid enum for enumstruct.Option"
+		fmt.Println("maybe")
+	}
+	Use(Option{"maybe?"}) // want "invalid enum for enumstruct.Option"
+	return Option{"no"}   // want "invalid enum for enumstruct.Option"
+}
+
+func Use(x Option) {}
@@ -0,0 +1,91 @@
+// want package:"enumtable.Day = {Friday | Monday}, enumtable.Letter = {Alpha | Beta | Gamma | numLetters}, enumtable.Option = {False | True}"
+package enumtable
+
+// Letter is an enumerated type.
+//
+//enumcheck:exhaustive
+type Letter byte
+
+const (
+	Alpha Letter = iota
+	Beta
+	Gamma
+	numLetters
+)
+
+var letterNames = map[Letter]string{ // want "missing keys Gamma and numLetters"
+	Alpha: "alpha",
+	Beta:  "beta",
+}
+
+var letterNamesInvalid = map[Letter]string{ // want "missing keys Gamma and numLetters"
+	Alpha: "alpha",
+	Beta:  "beta",
+	4:     "invalid", // want "implicit conversion of 4 to enumtable.Letter"
+}
+
+var letterNamesOverride = map[Letter]string{ //enumcheck:silent
+	Alpha: "alpha",
+}
+
+var letterNamesEmpty = map[Letter]string{}
+
+var letterArray = [...]string{ // want "missing keys Beta and numLetters"
+	Alpha: "alpha",
+	Gamma: "gamma",
+}
+
+var letterSentinel = [numLetters]string{ // want "missing keys Gamma"
+	"alpha",
+	"beta",
+}
+
+var letterSentinelKeyed = [numLetters]string{
+	Gamma: "gamma",
+	Alpha: "alpha",
+	Beta:  "beta",
+}
+
+var letterSentinelComplete = [numLetters]string{"alpha", "beta", "gamma"}
+
+var letterSlice = []int{1, 2, 3}
+
+func Local() {
+	names := map[Letter]int{ // want "missing keys Beta, Gamma and numLetters"
+		Alpha: 1,
+	}
+	_ = names
+}
+
+// Day is an enumerated type.
+//
+//enumcheck:silent
+type Day string
+
+const (
+	Monday = Day("monday")
+	Friday = Day("friday")
+)
+
+var dayNames = map[Day]string{
+	Monday: "monday",
+}
+
+var dayNamesExhaustive = map[Day]string{ //enumcheck:exhaustive // want "missing keys Friday"
+	Monday: "monday",
+}
+
+// Option is an enumerated type.
+//
+//enumcheck:relaxed
+type Option struct{ value string }
+
+var (
+	True  = Option{"true"}
+	False = Option{"false"}
+)
+
+var optionNames = map[Option]string{ // want "missing keys False"
+	True:              "true",
+	Option{"invalid"}: "invalid", // want "invalid enum for enumtable.Option"
+}
@@ -0,0 +1,92 @@
+// want package:"enumtransition.Phase = {Done | Failed | Idle | Running}, enumtransition.Step = {Start | Stop}"
+package enumtransition
+
+// Phase is the state of a job.
+//
+//enumcheck:exhaustive
+//enumcheck:transition Idle -> Running, Running -> Done|Failed, Failed -> Idle
+type Phase byte
+
+const (
+	Idle Phase = iota
+	Running
+	Done
+	Failed
+)
+
+//enumcheck:transition Alpha -> Finished // want "unknown value Finished in transition of enumtransition.Letter"
+type Letter byte //enumcheck
+
+const (
+	Alpha Letter = iota
+)
+
+type Job struct {
+	phase Phase
+}
+
+func (job *Job) Step(ok bool) {
+	switch job.phase {
+	case Idle:
+		job.phase = Running
+	case Running:
+		if !ok {
+			job.phase = Failed
+			job.phase = Idle
+			return
+		}
+		job.phase = Done
+	case Failed, Done:
+		job.phase = Idle // want "invalid transition Done -> Idle"
+	default:
+		job.phase = Failed
+	}
+}
+
+func (job *Job) Finish(ok bool) {
+	switch job.phase {
+	case Running:
+		if ok {
+			job.phase = Done
+		} else {
+			job.phase = Failed
+		}
+		job.phase = Idle // want "invalid transition Done -> Idle"
+	case Idle:
+		job.phase = Done // want "invalid transition Idle -> Done"
+		job.phase = Idle // want "invalid transition Done -> Idle"
+	case Done, Failed:
+	default:
+	}
+}
+
+func (job *Job) Restart() {
+	switch job.phase {
+	case Done:
+		func() {
+			job.phase = Idle
+		}()
+	case Idle, Running, Failed:
+		job.phase = Running // want "invalid transition Running -> Running" "invalid transition Failed -> Running"
+	default:
+	}
+}
+
+// Step declares the transitions before the mode.
+//
+//enumcheck:transition Start -> Stop
+//enumcheck:relaxed
+type Step byte
+
+const (
+	Start Step = iota
+	Stop
+)
+
+func (step *Step) Next() {
+	switch *step { // want `^missing cases Stop$`
+	case Start:
+		*step = Stop
+		*step = Start // want "invalid transition Stop -> Start"
+	}
+}
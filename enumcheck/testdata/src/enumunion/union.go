@@ -0,0 +1,139 @@
+// want package:"enumunion.NodeKind = {KindAdd | KindMul | KindNeg}, enumunion.OpKind = {OpAdd | OpCheckedAdd | OpSub}"
+package enumunion
+
+// NodeKind switches are complete, unless they switch over a union.
+//
+//enumcheck:complete
+type NodeKind byte
+
+const (
+	KindAdd NodeKind = iota
+	KindMul
+	KindNeg
+)
+
+type Binary struct{ Left, Right *Node }
+
+//enumcheck:union Kind
+type Node struct { // want Node:"union Kind {KindAdd: Add, KindMul: Mul, KindNeg: Negate}"
+	Kind NodeKind
+	Add  *Binary
+	Mul  *Binary
+	//enumcheck:case=KindNeg
+	Negate *Node
+}
+
+func Eval(n *Node) int {
+	switch n.Kind {
+	case KindAdd:
+		return Eval(n.Add.Left) + Eval(n.Add.Right)
+	case KindMul:
+		return Eval(n.Mul.Left) * Eval(n.Mul.Right)
+	case KindNeg:
+		return -Eval(n.Negate)
+	}
+	return 0
+}
+
+func Missing(n *Node) int {
+	switch n.Kind { // want "missing cases KindNeg"
+	case KindAdd:
+		return Eval(n.Add.Left) + Eval(n.Mul.Right) // want `n.Mul read outside of case KindMul`
+	case KindMul:
+		return Eval(n.Add.Left) // want `n.Add read outside of case KindAdd`
+	default:
+		return 0
+	}
+}
+
+func Left(n *Node) *Node {
+	if n.Kind == KindAdd && n.Add != nil {
+		return n.Add.Left
+	}
+	if n.Add != nil {
+		return n.Add.Left // want `n.Add read outside of case KindAdd`
+	}
+	return nil
+}
+
+func Right(n *Node) *Node {
+	if n.Kind == KindAdd && n.Add.Right != nil {
+		return n.Add.Right
+	}
+	if n.Kind != KindMul || n.Mul.Right == nil {
+		return nil
+	}
+	return n.Mul.Right
+}
+
+func Guard(n *Node) *Node {
+	if n.Kind != KindAdd {
+		return n.Mul.Left // want `n.Mul read outside of case KindMul`
+	}
+	return n.Add.Left
+}
+
+func Operands(n *Node) []*Node {
+	for {
+		if n.Kind != KindNeg {
+			break
+		}
+		n = n.Negate
+	}
+	if n.Kind != KindAdd && n.Kind != KindMul {
+		panic("not binary")
+	}
+	return []*Node{n.Add.Left, n.Add.Right} // want `n.Add read outside of case KindAdd` `n.Add read outside of case KindAdd`
+}
+
+func Condition(n *Node) bool {
+	return n.Add.Left != nil && n.Kind == KindAdd // want `n.Add read outside of case KindAdd`
+}
+
+func Reset(n *Node) {
+	n.Add = nil
+	n.Mul = nil
+}
+
+func Build(left, right *Node) []Node {
+	return []Node{
+		{Kind: KindAdd, Add: &Binary{left, right}},
+		{Kind: KindNeg, Negate: left},
+		{Kind: KindMul}, // want `Node with Kind KindMul must set Mul`
+		{Kind: KindAdd, Add: &Binary{left, right}, Mul: &Binary{left, right}}, // want `Node with Kind KindAdd must not set Mul`
+		{Kind: KindNeg, Add: nil, Negate: left},
+	}
+}
+
+//enumcheck:union Type
+type Invalid struct { // want "union Invalid does not have a field Type"
+	Kind NodeKind
+}
+
+//enumcheck:complete
+type OpKind byte
+
+const (
+	OpAdd OpKind = iota
+	OpCheckedAdd
+	OpSub
+)
+
+//enumcheck:union Kind
+type Op struct { // want Op:"union Kind {OpCheckedAdd: Checked, OpSub: Sub}"
+	Kind    OpKind
+	Add     *Binary // want "payload Add matches OpAdd and OpCheckedAdd, use enumcheck:case to pick one"
+	Checked *Binary //enumcheck:case=OpCheckedAdd
+	Sub     *Binary
+	//enumcheck:case=OpSub
+	Minus *Binary // want "payloads Sub and Minus both belong to OpSub"
+}
+
+func Name(kind NodeKind) string {
+	switch kind {
+	case KindAdd:
+		return "+"
+	default:
+		return "?"
+	}
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"errors"
+
+	"errorset"
+)
+
+func Chain(key string) string {
+	_, err := errorset.Find(key)
+	if errors.Is(err, errorset.ErrNotFound) {
+		return "not found"
+	} else if errors.Is(err, errorset.ErrTimeout) {
+		return "timeout"
+	} else if err != nil {
+		return "unknown"
+	}
+	return ""
+}
+
+func Equal(key string) string {
+	_, err := errorset.Find(key)
+	if err == errorset.ErrNotFound { // want `missing cases ErrTimeout and \*errorset.QueryError`
+		return "not found"
+	} else if errors.Is(err, nil) {
+		return "ok"
+	}
+	return ""
+}
+
+func Tagged(key string) string {
+	_, err := errorset.Find(key)
+	switch err { // want `missing cases ErrTimeout and \*errorset.QueryError`
+	case nil:
+		return "ok"
+	case errorset.ErrNotFound:
+		return "not found"
+	}
+	return ""
+}
+
+func Single(key string) string {
+	_, err := errorset.Find(key)
+	if errors.Is(err, errorset.ErrNotFound) {
+		return "not found"
+	}
+	return ""
+}
+
+func Else(key string) string {
+	_, err := errorset.Find(key)
+	if errors.Is(err, errorset.ErrNotFound) {
+		return "not found"
+	} else if errors.Is(err, errorset.ErrTimeout) {
+		return "timeout"
+	} else {
+		return "other"
+	}
+}
+
+func Default(key string) string {
+	_, err := errorset.Find(key)
+	switch err {
+	case errorset.ErrNotFound:
+		return "not found"
+	default:
+		return "other"
+	}
+}
+
+func Override(key string) string {
+	_, err := errorset.Find(key)
+	switch { //enumcheck:relaxed // want `missing cases ErrTimeout and \*errorset.QueryError`
+	case errors.Is(err, errorset.ErrNotFound):
+		return "not found"
+	default:
+		return "other"
+	}
+}
+
+func Put(key string) string {
+	err := errorset.Put(key)
+	switch { // want `missing cases ErrFull`
+	case errors.Is(err, errorset.ErrClosed):
+		return "closed"
+	case err != nil:
+		return "other"
+	}
+	return ""
+}
+
+func PutExhaustive(key string) string {
+	err := errorset.Put(key)
+	switch { //enumcheck:exhaustive // want `missing cases default`
+	case errors.Is(err, errorset.ErrClosed), errors.Is(err, errorset.ErrFull):
+		return "unavailable"
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+// want package:"errorset.Lookup = {ErrNotFound | ErrTimeout | [*]QueryError}, errorset.Store = {ErrClosed | ErrFull}"
+package errorset
+
+import (
+	"errors"
+	"fmt"
+)
+
+//enumcheck:errors Lookup
+var (
+	ErrNotFound = errors.New("not found")
+	ErrTimeout  = errors.New("timeout")
+)
+
+// QueryError is returned for invalid queries.
+//
+//enumcheck:errors Lookup
+type QueryError struct{ Query string }
+
+func (err *QueryError) Error() string { return fmt.Sprintf("invalid query %q", err.Query) }
+
+// Find finds a value by key.
+//
+//enumcheck:returns Lookup
+func Find(key string) (string, error) { // want Find:"returns errorset.Lookup"
+	if key == "" {
+		return "", &QueryError{Query: key}
+	}
+	return "", ErrNotFound
+}
+
+// Store errors need to be handled even with a catch-all.
+//
+//enumcheck:errors Store,relaxed
+var (
+	ErrFull   = errors.New("full")
+	ErrClosed = errors.New("closed")
+)
+
+// Put stores a value.
+//
+//enumcheck:returns Store
+func Put(key string) error { // want Put:"returns errorset.Store"
+	if key == "" {
+		return ErrClosed
+	}
+	return ErrFull
+}
+
+//enumcheck:returns Missing // want "unknown error set Missing"
+func Invalid() error { return nil }
+
+func Handle(key string) string {
+	_, err := Find(key)
+	switch { // want `missing cases ErrTimeout`
+	case errors.Is(err, ErrNotFound):
+		return "not found"
+	case errors.As(err, new(*QueryError)):
+		return "invalid"
+	}
+
+	var queryErr *QueryError
+	switch {
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrTimeout):
+		return "unavailable"
+	case errors.As(err, &queryErr):
+		return "invalid " + queryErr.Query
+	}
+
+	return ""
+}
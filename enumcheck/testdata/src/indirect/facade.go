@@ -0,0 +1,33 @@
+package indirect
+
+import (
+	"enumbyte"
+	"enumfacade"
+)
+
+func Facade(x enumfacade.Letter) string {
+	switch x {
+	case enumfacade.Alpha, enumfacade.Beta:
+		return "alpha or beta"
+	case enumfacade.Gamma, enumbyte.Delta, enumfacade.Eta:
+		return "other"
+	default:
+		return ""
+	}
+}
+
+func FacadeMissing(x enumfacade.Letter) string {
+	switch x { // want "missing cases Delta, Eta and Gamma"
+	case enumfacade.Alpha, enumfacade.Beta:
+		return "alpha or beta"
+	default:
+		return ""
+	}
+}
+
+var facadeNames = map[enumfacade.Letter]string{ // want "missing keys Eta"
+	enumfacade.Alpha: "alpha",
+	enumfacade.Beta:  "beta",
+	enumfacade.Gamma: "gamma",
+	enumfacade.Delta: "delta",
+}
@@ -0,0 +1,101 @@
+package indirect
+
+import "enumhidden"
+
+func Hidden(s enumhidden.State) string {
+	switch s { // want "missing default, enumhidden.State has unexported values that cannot be handled outside of package enumhidden"
+	case enumhidden.Idle:
+		return "idle"
+	case enumhidden.Running:
+		return "running"
+	case enumhidden.Done:
+		return "done"
+	}
+	return ""
+}
+
+func HiddenMissing(s enumhidden.State) string {
+	switch s { // want "missing default, enumhidden.State has unexported values that cannot be handled outside of package enumhidden" "missing cases Done"
+	case enumhidden.Idle:
+		return "idle"
+	case enumhidden.Running:
+		return "running"
+	}
+	return ""
+}
+
+func HiddenDefault(s enumhidden.State) string {
+	switch s {
+	case enumhidden.Idle:
+		return "idle"
+	case enumhidden.Running:
+		return "running"
+	case enumhidden.Done:
+		return "done"
+	default:
+		return "internal"
+	}
+}
+
+func HiddenRelaxed(s enumhidden.State) string {
+	switch s { //enumcheck:relaxed // want "missing default, enumhidden.State has unexported values that cannot be handled outside of package enumhidden"
+	case enumhidden.Idle, enumhidden.Running, enumhidden.Done:
+		return "state"
+	}
+	return ""
+}
+
+var HiddenNames = map[enumhidden.State]string{
+	enumhidden.Idle:    "idle",
+	enumhidden.Running: "running",
+	enumhidden.Done:    "done",
+}
+
+var HiddenMissingNames = map[enumhidden.State]string{ // want "missing keys Done"
+	enumhidden.Idle:    "idle",
+	enumhidden.Running: "running",
+}
+
+var HiddenLabels = [...]string{
+	enumhidden.Idle:    "idle",
+	enumhidden.Running: "running",
+	enumhidden.Done:    "done",
+}
+
+//enumcheck:all
+var HiddenStates = []enumhidden.State{enumhidden.Idle, enumhidden.Running, enumhidden.Done}
+
+//enumcheck:all
+var HiddenMissingStates = []enumhidden.State{enumhidden.Idle, enumhidden.Running} // want "missing values Done"
+
+func HiddenEvent(e enumhidden.Event) string {
+	switch e.(type) { // want "missing default, enumhidden.Event has unexported types that cannot be handled outside of package enumhidden"
+	case enumhidden.Start:
+		return "start"
+	case enumhidden.Stop:
+		return "stop"
+	}
+	return ""
+}
+
+func HiddenEventMissing(e enumhidden.Event) string {
+	switch e.(type) { // want "missing default, enumhidden.Event has unexported types that cannot be handled outside of package enumhidden" "missing cases enumhidden.Stop"
+	case enumhidden.Start:
+		return "start"
+	}
+	return ""
+}
+
+func HiddenEventDefault(e enumhidden.Event) string {
+	switch e.(type) {
+	case enumhidden.Start:
+		return "start"
+	case enumhidden.Stop:
+		return "stop"
+	default:
+		return "internal"
+	}
+}
+
+//enumcheck:all
+var HiddenEvents = []enumhidden.Event{enumhidden.Start{}, enumhidden.Stop{}}
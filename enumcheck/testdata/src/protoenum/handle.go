@@ -0,0 +1,39 @@
+package protoenum
+
+import "protoenum/pb"
+
+func Kind(msg *pb.Msg) string {
+	switch msg.Kind { // want "missing cases Kind_KIND_MUL"
+	case pb.Kind_KIND_UNSPECIFIED:
+		return "?"
+	case pb.Kind_KIND_ADD:
+		return "+"
+	}
+	return ""
+}
+
+func Complete(msg *pb.Msg) string {
+	switch msg.Kind {
+	case pb.Kind_KIND_ADD:
+		return "+"
+	case pb.Kind_KIND_MUL:
+		return "*"
+	}
+	return ""
+}
+
+func Payload(msg *pb.Msg) string {
+	switch p := msg.GetPayload().(type) { // want "missing cases [*]protoenum/pb.Msg_Number"
+	case *pb.Msg_Text:
+		return p.Text
+	}
+	return ""
+}
+
+func Status(status pb.Status) string {
+	switch status {
+	case pb.StatusOK:
+		return "ok"
+	}
+	return ""
+}
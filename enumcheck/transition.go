@@ -0,0 +1,336 @@
+package enumcheck
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// collectTransitions finds state machines declared on enums.
+//
+//	//enumcheck:exhaustive
+//	//enumcheck:transition Idle -> Running, Running -> Done|Failed
+//	type Phase byte
+func collectTransitions(reportf reportFn, pass *analysis.Pass, pkgEnums enumSet) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				ts := spec.(*ast.TypeSpec)
+				obj := pass.TypesInfo.Defs[ts.Name]
+				if obj == nil {
+					continue
+				}
+				enum, ok := pkgEnums[obj.Type()]
+				if !ok || enum.TypeEnum {
+					continue
+				}
+
+				groups := []*ast.CommentGroup{ts.Doc, ts.Comment}
+				if len(decl.Specs) == 1 {
+					groups = append(groups, decl.Doc)
+				}
+				for _, group := range groups {
+					if group == nil {
+						continue
+					}
+					for _, c := range group.List {
+						if x, ok := isEnumcheckComment(c.Text); ok && x.transitions != "" {
+							addTransitions(reportf, enum, c.Pos(), x.transitions)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// addTransitions parses `Idle -> Running, Running -> Done|Failed`.
+func addTransitions(reportf reportFn, enum *enum, pos token.Pos, spec string) {
+	lookup := func(names string) []types.Object {
+		var values []types.Object
+		for _, name := range strings.Split(names, "|") {
+			name = strings.TrimSpace(name)
+			found := false
+			for _, obj := range enum.Values {
+				if obj.Name() == name {
+					values = append(values, obj)
+					found = true
+					break
+				}
+			}
+			if !found {
+				reportf(pos, "unknown value %v in transition of %v", name, enum.Type)
+			}
+		}
+		return values
+	}
+
+	if enum.Transitions == nil {
+		enum.Transitions = map[types.Object][]types.Object{}
+	}
+	for _, transition := range strings.Split(spec, ",") {
+		from, to, ok := strings.Cut(transition, "->")
+		if !ok {
+			reportf(pos, "invalid transition %q, expected `From -> To`", strings.TrimSpace(transition))
+			continue
+		}
+		targets := lookup(to)
+		for _, source := range lookup(from) {
+			for _, target := range targets {
+				if !containsObject(enum.Transitions[source], target) {
+					enum.Transitions[source] = append(enum.Transitions[source], target)
+				}
+			}
+		}
+	}
+}
+
+// verifyTransitions checks assignments to the switch subject against the
+// declared transitions. Assignments are checked against the values that the
+// subject may have at that point, starting from the case values.
+//
+//	switch s.phase {
+//	case Idle:
+//		s.phase = Running // ok
+//	case Running:
+//		s.phase = Idle // error
+//	}
+func verifyTransitions(reportf reportFn, pass *analysis.Pass, enums enumSet, n *ast.SwitchStmt) {
+	if n.Tag == nil {
+		return
+	}
+	enum, ok := enums.Of(pass.TypesInfo.TypeOf(n.Tag))
+	if !ok || enum.Transitions == nil {
+		return
+	}
+	subject := types.ExprString(ast.Unparen(n.Tag))
+
+	// walk returns the values the subject may have after stmts.
+	var walk func(stmts []ast.Stmt, current transitionState) transitionState
+	walkClauses := func(clauses []ast.Stmt, current transitionState) transitionState {
+		result := current
+		for _, clause := range clauses {
+			switch clause := clause.(type) {
+			case *ast.CaseClause:
+				result = result.merge(walk(clause.Body, current))
+			case *ast.CommClause:
+				result = result.merge(walk(clause.Body, current))
+			}
+		}
+		return result
+	}
+	walk = func(stmts []ast.Stmt, current transitionState) transitionState {
+		for _, stmt := range stmts {
+			switch stmt := stmt.(type) {
+			case *ast.AssignStmt:
+				if stmt.Tok != token.ASSIGN || len(stmt.Lhs) != len(stmt.Rhs) {
+					continue
+				}
+				for i, lhs := range stmt.Lhs {
+					if types.ExprString(ast.Unparen(lhs)) != subject {
+						continue
+					}
+					target := valueObject(pass, stmt.Rhs[i])
+					if target == nil {
+						current = transitionState{unknown: true}
+						continue
+					}
+					if !current.unknown {
+						for _, source := range current.values {
+							if !containsObject(enum.Transitions[source], target) {
+								reportf(stmt.Rhs[i].Pos(), "invalid transition %v -> %v", source.Name(), target.Name())
+							}
+						}
+					}
+					current = transitionState{values: []types.Object{target}}
+				}
+			case *ast.ReturnStmt, *ast.BranchStmt:
+				// the rest of the statements are not reached from here
+				return transitionState{}
+			case *ast.BlockStmt:
+				current = walk(stmt.List, current)
+			case *ast.LabeledStmt:
+				current = walk([]ast.Stmt{stmt.Stmt}, current)
+			case *ast.IfStmt:
+				then := walk(stmt.Body.List, current)
+				if stmt.Else != nil {
+					current = walk([]ast.Stmt{stmt.Else}, current)
+				}
+				current = current.merge(then)
+			case *ast.ForStmt:
+				current = current.merge(walk(stmt.Body.List, current))
+			case *ast.RangeStmt:
+				current = current.merge(walk(stmt.Body.List, current))
+			case *ast.SwitchStmt:
+				if stmt.Tag != nil && types.ExprString(ast.Unparen(stmt.Tag)) == subject {
+					// nested switches on the same subject are checked separately
+					current = transitionState{unknown: true}
+					continue
+				}
+				current = walkClauses(stmt.Body.List, current)
+			case *ast.TypeSwitchStmt:
+				current = walkClauses(stmt.Body.List, current)
+			case *ast.SelectStmt:
+				current = walkClauses(stmt.Body.List, current)
+			}
+		}
+		return current
+	}
+
+	for _, clause := range n.Body.List {
+		clause := clause.(*ast.CaseClause)
+
+		var sources []types.Object
+		for _, option := range clause.List {
+			if obj := valueObject(pass, option); obj != nil {
+				sources = append(sources, obj)
+			}
+		}
+		if len(sources) == 0 {
+			continue
+		}
+
+		walk(clause.Body, transitionState{values: sources})
+	}
+}
+
+// transitionState are the values that the subject of a switch may have.
+type transitionState struct {
+	values  []types.Object
+	unknown bool // the subject was assigned a value that is not an enum value
+}
+
+// merge returns the values of either state.
+func (state transitionState) merge(other transitionState) transitionState {
+	merged := transitionState{
+		values:  append([]types.Object(nil), state.values...),
+		unknown: state.unknown || other.unknown,
+	}
+	for _, obj := range other.values {
+		if !containsObject(merged.values, obj) {
+			merged.values = append(merged.values, obj)
+		}
+	}
+	return merged
+}
+
+// valueObject returns the enum value that expr refers to.
+func valueObject(pass *analysis.Pass, expr ast.Expr) types.Object {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return memberOf(pass, pass.TypesInfo.ObjectOf(expr))
+	case *ast.SelectorExpr:
+		return memberOf(pass, pass.TypesInfo.ObjectOf(expr.Sel))
+	}
+	return nil
+}
+
+// transitionsFlag is the format of the `-transitions` flag.
+type transitionsFlag string
+
+func (f *transitionsFlag) String() string { return string(*f) }
+
+func (f *transitionsFlag) Set(format string) error {
+	switch format {
+	case "", "dot", "mermaid":
+		*f = transitionsFlag(format)
+		return nil
+	default:
+		return fmt.Errorf("unknown transitions format %q, expected dot or mermaid", format)
+	}
+}
+
+// transitionsOutput is where the state machines are printed.
+var transitionsOutput = struct {
+	sync.Mutex
+	w io.Writer
+}{w: os.Stdout}
+
+// printTransitions prints the state machines declared in the current package.
+// Every enum is declared in a single package, so each diagram is printed once.
+// Packages may be analyzed concurrently, hence the diagrams of a package are
+// written in a single call.
+func printTransitions(format string, pkgEnums enumSet) error {
+	var buf bytes.Buffer
+	for _, enum := range sortedEnums(pkgEnums) {
+		if len(enum.Transitions) == 0 {
+			continue
+		}
+		if err := renderTransitions(&buf, format, enum); err != nil {
+			return err
+		}
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+
+	transitionsOutput.Lock()
+	defer transitionsOutput.Unlock()
+	_, err := transitionsOutput.w.Write(buf.Bytes())
+	return err
+}
+
+// renderTransitions writes the state machine of enum as a Graphviz or
+// a Mermaid diagram.
+func renderTransitions(w io.Writer, format string, enum *enum) error {
+	switch format {
+	case "dot":
+		fmt.Fprintf(w, "digraph %q {\n", enum.Type.String())
+		forEachTransition(enum, func(from, to types.Object) {
+			fmt.Fprintf(w, "\t%v -> %v;\n", from.Name(), to.Name())
+		})
+		fmt.Fprintf(w, "}\n")
+	case "mermaid":
+		fmt.Fprintf(w, "---\ntitle: %v\n---\nstateDiagram-v2\n", enum.Type.String())
+		forEachTransition(enum, func(from, to types.Object) {
+			fmt.Fprintf(w, "\t%v --> %v\n", from.Name(), to.Name())
+		})
+	default:
+		return fmt.Errorf("unknown transitions format %q, expected dot or mermaid", format)
+	}
+	return nil
+}
+
+// forEachTransition calls fn for every transition in declaration order.
+func forEachTransition(enum *enum, fn func(from, to types.Object)) {
+	sources := append([]types.Object(nil), enum.Values...)
+	sortByPos(sources)
+	for _, from := range sources {
+		targets := append([]types.Object(nil), enum.Transitions[from]...)
+		sortByPos(targets)
+		for _, to := range targets {
+			fn(from, to)
+		}
+	}
+}
+
+func sortByPos(objs []types.Object) {
+	sort.SliceStable(objs, func(i, k int) bool {
+		return objs[i].Pos() < objs[k].Pos()
+	})
+}
+
+// sortedEnums returns enums sorted by the type name.
+func sortedEnums(set enumSet) []*enum {
+	enums := make([]*enum, 0, len(set))
+	for _, enum := range set {
+		enums = append(enums, enum)
+	}
+	sort.Slice(enums, func(i, k int) bool {
+		return enums[i].Type.String() < enums[k].Type.String()
+	})
+	return enums
+}
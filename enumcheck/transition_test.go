@@ -0,0 +1,102 @@
+package enumcheck
+
+import (
+	"bytes"
+	"go/token"
+	"go/types"
+	"os"
+	"testing"
+)
+
+func TestRenderTransitions(t *testing.T) {
+	pkg := types.NewPackage("example.com/job", "job")
+	typ := types.NewNamed(types.NewTypeName(token.Pos(1), pkg, "Phase", nil), types.Typ[types.Uint8], nil)
+
+	value := func(pos int, name string) types.Object {
+		return types.NewConst(token.Pos(pos), pkg, name, typ, nil)
+	}
+	idle, running, done, failed := value(2, "Idle"), value(3, "Running"), value(4, "Done"), value(5, "Failed")
+
+	enum := &enum{
+		Type:   typ,
+		Values: []types.Object{done, failed, idle, running},
+		Transitions: map[types.Object][]types.Object{
+			idle:    {running},
+			running: {failed, done},
+			failed:  {idle},
+		},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"dot", "" +
+			"digraph \"example.com/job.Phase\" {\n" +
+			"\tIdle -> Running;\n" +
+			"\tRunning -> Done;\n" +
+			"\tRunning -> Failed;\n" +
+			"\tFailed -> Idle;\n" +
+			"}\n"},
+		{"mermaid", "" +
+			"---\n" +
+			"title: example.com/job.Phase\n" +
+			"---\n" +
+			"stateDiagram-v2\n" +
+			"\tIdle --> Running\n" +
+			"\tRunning --> Done\n" +
+			"\tRunning --> Failed\n" +
+			"\tFailed --> Idle\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		if err := renderTransitions(&buf, test.format, enum); err != nil {
+			t.Fatalf("%v: %v", test.format, err)
+		}
+		if got := buf.String(); got != test.want {
+			t.Errorf("%v: got\n%v\nwant\n%v", test.format, got, test.want)
+		}
+	}
+}
+
+func TestPrintTransitions(t *testing.T) {
+	pkg := types.NewPackage("example.com/letter", "letter")
+	typ := types.NewNamed(types.NewTypeName(token.Pos(1), pkg, "Letter", nil), types.Typ[types.Uint8], nil)
+	alpha := types.NewConst(token.Pos(2), pkg, "Alpha", typ, nil)
+	beta := types.NewConst(token.Pos(3), pkg, "Beta", typ, nil)
+
+	plain := types.NewNamed(types.NewTypeName(token.Pos(4), pkg, "Plain", nil), types.Typ[types.Uint8], nil)
+
+	var buf bytes.Buffer
+	transitionsOutput.w = &buf
+	defer func() { transitionsOutput.w = os.Stdout }()
+
+	err := printTransitions("dot", enumSet{
+		typ: {
+			Type:        typ,
+			Values:      []types.Object{alpha, beta},
+			Transitions: map[types.Object][]types.Object{alpha: {beta}},
+		},
+		plain: {Type: plain},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "digraph \"example.com/letter.Letter\" {\n\tAlpha -> Beta;\n}\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestTransitionsFlag(t *testing.T) {
+	var format transitionsFlag
+	for _, valid := range []string{"dot", "mermaid", ""} {
+		if err := format.Set(valid); err != nil {
+			t.Errorf("%q: %v", valid, err)
+		}
+	}
+	if err := format.Set("svg"); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+}
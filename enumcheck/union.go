@@ -0,0 +1,452 @@
+package enumcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// union is a struct where the kind field decides which payload field is valid.
+//
+//	//enumcheck:union Kind
+//	type Node struct {
+//		Kind NodeKind
+//		Add  *AddNode
+//		Mul  *MulNode
+//	}
+type union struct {
+	Type types.Type
+	Kind *types.Var
+	Enum *enum
+
+	// Payloads maps the kind values to the payload fields.
+	Payloads map[types.Object]*types.Var
+}
+
+// unionFact marks a struct annotated with `//enumcheck:union Kind`.
+type unionFact struct {
+	union *union
+}
+
+func (*unionFact) AFact() {}
+func (fact *unionFact) String() string {
+	names := []string{}
+	for _, obj := range fact.union.Enum.Values {
+		if field, ok := fact.union.Payloads[obj]; ok {
+			names = append(names, obj.Name()+": "+field.Name())
+		}
+	}
+	return "union " + fact.union.Kind.Name() + " {" + strings.Join(names, ", ") + "}"
+}
+
+// collectUnions finds structs annotated with `//enumcheck:union Kind`.
+//
+// A payload field belongs to the kind value whose name ends with the field
+// name, e.g. `Add` belongs to `KindAdd`, unless the field is annotated with
+// `//enumcheck:case=KindAdd`. Fields that match several values and values
+// that match several fields are reported.
+func collectUnions(reportf reportFn, pass *analysis.Pass, enums enumSet) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				ts := spec.(*ast.TypeSpec)
+				name := unionComment(ts.Doc)
+				if name == "" {
+					name = unionComment(ts.Comment)
+				}
+				if name == "" && len(decl.Specs) == 1 {
+					name = unionComment(decl.Doc)
+				}
+				if name == "" {
+					continue
+				}
+
+				obj := pass.TypesInfo.Defs[ts.Name]
+				if obj == nil {
+					continue
+				}
+				structType, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					reportf(ts.Pos(), "enumcheck:union %v must be a struct", obj.Name())
+					continue
+				}
+				if u := newUnion(reportf, pass, enums, obj, structType, name); u != nil {
+					pass.ExportObjectFact(obj, &unionFact{u})
+				}
+			}
+		}
+	}
+}
+
+// newUnion matches the payload fields of a union to the kind values.
+func newUnion(reportf reportFn, pass *analysis.Pass, enums enumSet, obj types.Object, structType *ast.StructType, kindName string) *union {
+	u := &union{
+		Type:     obj.Type(),
+		Payloads: map[types.Object]*types.Var{},
+	}
+
+	for _, field := range structType.Fields.List {
+		for _, name := range field.Names {
+			if name.Name == kindName {
+				u.Kind, _ = pass.TypesInfo.Defs[name].(*types.Var)
+			}
+		}
+	}
+	if u.Kind == nil {
+		reportf(structType.Pos(), "union %v does not have a field %v", obj.Name(), kindName)
+		return nil
+	}
+	enum, ok := enums.Of(u.Kind.Type())
+	if !ok || enum.TypeEnum {
+		reportf(structType.Pos(), "union field %v is not an enum", kindName)
+		return nil
+	}
+	u.Enum = enum
+
+	for _, field := range structType.Fields.List {
+		caseName := unionCaseComment(field.Doc)
+		if caseName == "" {
+			caseName = unionCaseComment(field.Comment)
+		}
+		for _, name := range field.Names {
+			if name.Name == kindName {
+				continue
+			}
+			payload, ok := pass.TypesInfo.Defs[name].(*types.Var)
+			if !ok {
+				continue
+			}
+
+			var matches []types.Object
+			for _, value := range enum.Values {
+				if caseName != "" && value.Name() == caseName ||
+					caseName == "" && strings.HasSuffix(value.Name(), name.Name) {
+					matches = append(matches, value)
+				}
+			}
+			if len(matches) == 0 {
+				if caseName != "" {
+					reportf(name.Pos(), "unknown value %v for payload %v", caseName, name.Name)
+				}
+				continue
+			}
+			if len(matches) > 1 {
+				names := []string{}
+				for _, value := range matches {
+					names = append(names, value.Name())
+				}
+				reportf(name.Pos(), "payload %v matches %v, use enumcheck:case to pick one", name.Name, humaneList(names))
+				continue
+			}
+
+			member := matches[0]
+			if other, exists := u.Payloads[member]; exists {
+				reportf(name.Pos(), "payloads %v and %v both belong to %v", other.Name(), name.Name, member.Name())
+				continue
+			}
+			u.Payloads[member] = payload
+		}
+	}
+	return u
+}
+
+func unionComment(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	for _, c := range group.List {
+		if c, ok := isEnumcheckComment(c.Text); ok && c.union != "" {
+			return c.union
+		}
+	}
+	return ""
+}
+
+func unionCaseComment(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	for _, c := range group.List {
+		if c, ok := isEnumcheckComment(c.Text); ok && c.unionCase != "" {
+			return c.unionCase
+		}
+	}
+	return ""
+}
+
+// unionOf returns the union of a struct type or a pointer to it.
+func unionOf(pass *analysis.Pass, typ types.Type) (*union, bool) {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok {
+		return nil, false
+	}
+	var fact unionFact
+	if !pass.ImportObjectFact(named.Obj(), &fact) {
+		return nil, false
+	}
+	return fact.union, true
+}
+
+// isUnionKind returns whether expr selects the kind field of a union.
+func isUnionKind(pass *analysis.Pass, expr ast.Expr) bool {
+	sel, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	u, ok := unionOf(pass, pass.TypesInfo.TypeOf(sel.X))
+	return ok && pass.TypesInfo.ObjectOf(sel.Sel) == u.Kind
+}
+
+// verifyUnionRead reports payload fields that are read outside of a case
+// or an if statement for the matching kind. Reads are also allowed after
+// `n.Kind == KindAdd &&` and after a guard that returns for other kinds.
+//
+//	switch n.Kind {
+//	case KindAdd:
+//		eval(n.Add) // ok
+//		eval(n.Mul) // error
+//	}
+//
+//	if n.Kind != KindAdd {
+//		return
+//	}
+//	eval(n.Add) // ok
+func verifyUnionRead(reportf reportFn, pass *analysis.Pass, sel *ast.SelectorExpr, stack []ast.Node) {
+	u, ok := unionOf(pass, pass.TypesInfo.TypeOf(sel.X))
+	if !ok {
+		return
+	}
+	field := pass.TypesInfo.ObjectOf(sel.Sel)
+	var member types.Object
+	for value, payload := range u.Payloads {
+		if payload == field {
+			member = value
+		}
+	}
+	if member == nil {
+		return
+	}
+
+	// writes and nil checks are allowed
+	if len(stack) >= 2 {
+		switch parent := stack[len(stack)-2].(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range parent.Lhs {
+				if lhs == sel {
+					return
+				}
+			}
+		case *ast.BinaryExpr:
+			if (parent.Op == token.EQL || parent.Op == token.NEQ) && (isNil(pass, parent.X) || isNil(pass, parent.Y)) {
+				return
+			}
+		}
+	}
+
+	subject := types.ExprString(ast.Unparen(sel.X))
+	isKind := func(expr ast.Expr) bool {
+		kind, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+		return ok && types.ExprString(ast.Unparen(kind.X)) == subject && pass.TypesInfo.ObjectOf(kind.Sel) == u.Kind
+	}
+
+outer:
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncLit, *ast.FuncDecl:
+			break outer
+		case *ast.CaseClause:
+			if i < 2 {
+				continue
+			}
+			sw, ok := stack[i-2].(*ast.SwitchStmt)
+			if !ok || sw.Tag == nil || !isKind(sw.Tag) {
+				continue
+			}
+			if len(n.List) == 1 && valueObject(pass, n.List[0]) == member {
+				return
+			}
+			if kindGuarded(pass, n.Body, stack[i+1], isKind) == member {
+				return
+			}
+		case *ast.BlockStmt:
+			if kindGuarded(pass, n.List, stack[i+1], isKind) == member {
+				return
+			}
+		case *ast.IfStmt:
+			if stack[i+1] == n.Body && kindCondition(pass, n.Cond, isKind) == member {
+				return
+			}
+		case *ast.BinaryExpr:
+			if stack[i+1] != n.Y {
+				continue
+			}
+			// n.Kind == KindAdd && n.Add.Left != nil
+			if n.Op == token.LAND && kindCondition(pass, n.X, isKind) == member {
+				return
+			}
+			// n.Kind != KindAdd || n.Add.Left == nil
+			if n.Op == token.LOR && kindExclusion(pass, n.X, isKind) == member {
+				return
+			}
+		}
+	}
+
+	reportf(sel.Sel.Pos(), "%v read outside of case %v", types.ExprString(sel), member.Name())
+}
+
+// kindCondition returns the kind value that cond requires, e.g.
+// `n.Kind == KindAdd && ...`.
+func kindCondition(pass *analysis.Pass, cond ast.Expr, isKind func(ast.Expr) bool) types.Object {
+	binary, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+	if !ok {
+		return nil
+	}
+	switch binary.Op {
+	case token.LAND:
+		if obj := kindCondition(pass, binary.X, isKind); obj != nil {
+			return obj
+		}
+		return kindCondition(pass, binary.Y, isKind)
+	case token.EQL:
+		if isKind(binary.X) {
+			return valueObject(pass, binary.Y)
+		}
+		if isKind(binary.Y) {
+			return valueObject(pass, binary.X)
+		}
+	}
+	return nil
+}
+
+// kindExclusion returns the kind value that cond rules out, e.g.
+// `n.Kind != KindAdd || ...`.
+func kindExclusion(pass *analysis.Pass, cond ast.Expr, isKind func(ast.Expr) bool) types.Object {
+	binary, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+	if !ok {
+		return nil
+	}
+	switch binary.Op {
+	case token.LOR:
+		if obj := kindExclusion(pass, binary.X, isKind); obj != nil {
+			return obj
+		}
+		return kindExclusion(pass, binary.Y, isKind)
+	case token.NEQ:
+		if isKind(binary.X) {
+			return valueObject(pass, binary.Y)
+		}
+		if isKind(binary.Y) {
+			return valueObject(pass, binary.X)
+		}
+	}
+	return nil
+}
+
+// kindGuarded returns the kind value that is guaranteed at stmt by an
+// earlier if statement in stmts that leaves for the other kinds.
+//
+//	if n.Kind != KindAdd {
+//		return
+//	}
+func kindGuarded(pass *analysis.Pass, stmts []ast.Stmt, stmt ast.Node, isKind func(ast.Expr) bool) types.Object {
+	for _, prev := range stmts {
+		if prev == stmt {
+			break
+		}
+		guard, ok := prev.(*ast.IfStmt)
+		if !ok || guard.Init != nil || guard.Else != nil || !leavesBlock(pass, guard.Body) {
+			continue
+		}
+		if obj := kindExclusion(pass, guard.Cond, isKind); obj != nil {
+			return obj
+		}
+	}
+	return nil
+}
+
+// leavesBlock returns whether block ends with a return, a branch or a call
+// that doesn't return.
+func leavesBlock(pass *analysis.Pass, block *ast.BlockStmt) bool {
+	if len(block.List) == 0 {
+		return false
+	}
+	switch last := block.List[len(block.List)-1].(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true
+	case *ast.ExprStmt:
+		call, ok := ast.Unparen(last.X).(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		if ident, ok := ast.Unparen(call.Fun).(*ast.Ident); ok {
+			if builtin, ok := pass.TypesInfo.Uses[ident].(*types.Builtin); ok && builtin.Name() == "panic" {
+				return true
+			}
+		}
+		return isUnreachableCall(pass, call)
+	}
+	return false
+}
+
+// verifyUnionLiteral checks that a union literal sets the payload that
+// matches the kind.
+//
+//	Node{Kind: KindAdd, Add: &AddNode{}} // ok
+//	Node{Kind: KindAdd, Mul: &MulNode{}} // error
+func verifyUnionLiteral(reportf reportFn, pass *analysis.Pass, lit *ast.CompositeLit) {
+	u, ok := unionOf(pass, pass.TypesInfo.TypeOf(lit))
+	if !ok {
+		return
+	}
+
+	var kind types.Object
+	kindSet := false
+	fields := map[types.Object]bool{}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			return
+		}
+		field := pass.TypesInfo.ObjectOf(key)
+		if field == u.Kind {
+			kind = valueObject(pass, kv.Value)
+			kindSet = true
+			continue
+		}
+		if !isNil(pass, kv.Value) {
+			fields[field] = true
+		}
+	}
+	if !kindSet {
+		return
+	}
+	if kind == nil || !containsObject(u.Enum.Values, kind) {
+		// computed kinds can't be verified
+		return
+	}
+
+	typeName := types.TypeString(u.Type, types.RelativeTo(pass.Pkg))
+	if payload, ok := u.Payloads[kind]; ok && !fields[payload] {
+		reportf(lit.Pos(), "%v with %v %v must set %v", typeName, u.Kind.Name(), kind.Name(), payload.Name())
+	}
+	for _, value := range u.Enum.Values {
+		payload, ok := u.Payloads[value]
+		if ok && value != kind && fields[payload] {
+			reportf(lit.Pos(), "%v with %v %v must not set %v", typeName, u.Kind.Name(), kind.Name(), payload.Name())
+		}
+	}
+}